@@ -0,0 +1,138 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ACLLevel is the access level of an ACL action.
+type ACLLevel string
+
+// ACL levels. Entities with plain boolean access use ACLYes and ACLNo; the others
+// grant an action for all records, the user's teams' records or their own records.
+const (
+	ACLYes  ACLLevel = "yes"
+	ACLNo   ACLLevel = "no"
+	ACLAll  ACLLevel = "all"
+	ACLTeam ACLLevel = "team"
+	ACLOwn  ACLLevel = "own"
+)
+
+// ACL actions.
+const (
+	ActionCreate = "create"
+	ActionRead   = "read"
+	ActionEdit   = "edit"
+	ActionDelete = "delete"
+	ActionStream = "stream"
+)
+
+// aclActions are the actions reported for entities with boolean ACL.
+var aclActions = []string{ActionCreate, ActionRead, ActionEdit, ActionDelete, ActionStream}
+
+// Ownership relates a record to the user for ACL checks.
+type Ownership int
+
+const (
+	// OwnershipOther is a record neither assigned to the user nor to their teams.
+	OwnershipOther Ownership = iota
+	// OwnershipTeam is a record of one of the user's teams.
+	OwnershipTeam
+	// OwnershipOwn is a record assigned to (or created by) the user.
+	OwnershipOwn
+)
+
+// ACL is the access control table of the authenticated user, as found in App/user.
+type ACL struct {
+	// Table maps entity types to the level of each action.
+	Table map[string]map[string]ACLLevel
+	// AssignmentPermission and UserPermission are "all", "team" or "no".
+	AssignmentPermission ACLLevel
+	UserPermission       ACLLevel
+}
+
+// UnmarshalJSON decodes the App/user ACL, expanding boolean entries of the table
+// to the yes/no level of every action.
+func (a *ACL) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Table                map[string]json.RawMessage `json:"table"`
+		AssignmentPermission ACLLevel                   `json:"assignmentPermission"`
+		UserPermission       ACLLevel                   `json:"userPermission"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	a.AssignmentPermission, a.UserPermission = raw.AssignmentPermission, raw.UserPermission
+	a.Table = make(map[string]map[string]ACLLevel, len(raw.Table))
+	for entity, entry := range raw.Table {
+		var allowed bool
+		if json.Unmarshal(entry, &allowed) == nil {
+			level := ACLNo
+			if allowed {
+				level = ACLYes
+			}
+			actions := make(map[string]ACLLevel, len(aclActions))
+			for _, action := range aclActions {
+				actions[action] = level
+			}
+			a.Table[entity] = actions
+			continue
+		}
+		var actions map[string]ACLLevel
+		if json.Unmarshal(entry, &actions) == nil {
+			a.Table[entity] = actions
+		}
+	}
+	return nil
+}
+
+// Level returns the level of an action on an entity type; ACLNo if not listed.
+func (a *ACL) Level(entity, action string) ACLLevel {
+	if level, ok := a.Table[entity][action]; ok {
+		return level
+	}
+	return ACLNo
+}
+
+// Can reports whether the user may perform an action on a record of the entity
+// type with the given ownership.
+func (a *ACL) Can(entity, action string, ownership Ownership) bool {
+	switch a.Level(entity, action) {
+	case ACLYes, ACLAll:
+		return true
+	case ACLTeam:
+		return ownership >= OwnershipTeam
+	case ACLOwn:
+		return ownership == OwnershipOwn
+	}
+	return false
+}
+
+// CanCreate reports whether the user may create records of the entity type.
+func (a *ACL) CanCreate(entity string) bool {
+	return a.Level(entity, ActionCreate) != ACLNo
+}
+
+// CanRead reports whether the user may read a record with the given ownership.
+func (a *ACL) CanRead(entity string, ownership Ownership) bool {
+	return a.Can(entity, ActionRead, ownership)
+}
+
+// CanEdit reports whether the user may edit a record with the given ownership.
+func (a *ACL) CanEdit(entity string, ownership Ownership) bool {
+	return a.Can(entity, ActionEdit, ownership)
+}
+
+// CanDelete reports whether the user may delete a record with the given ownership.
+func (a *ACL) CanDelete(entity string, ownership Ownership) bool {
+	return a.Can(entity, ActionDelete, ownership)
+}
+
+// ACL returns the access control table of the authenticated user.
+func (c *Client) ACL(ctx context.Context) (*ACL, error) {
+	user, err := c.GetAppUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &user.ACL, nil
+}
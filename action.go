@@ -0,0 +1,92 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Action calls a controller action of the entity type with POST
+// {entity}/action/{name}, the way the UI calls backend actions such as the lead
+// conversion, and returns the response body. A non-empty id is sent as the "id"
+// attribute of payload, which may be nil. Custom actions added to EspoCRM
+// controllers (postActionName methods) are called the same way.
+func (c *Client) Action(ctx context.Context, entity, id, name string, payload map[string]any) (json.RawMessage, error) {
+	body := make(map[string]any, len(payload)+1)
+	for k, v := range payload {
+		body[k] = v
+	}
+	if id != "" {
+		body["id"] = id
+	}
+	resp, err := c.request(ctx, MethodPost, entity+"/action/"+url.PathEscape(name), body, nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(resp.Body), nil
+}
+
+// RecordAction runs an action of the record action framework (EspoCRM 7.2 and
+// later) on a record with POST Action, e.g. "convertCurrency", and returns the
+// response body, usually the record as stored. data may be nil.
+func (c *Client) RecordAction(ctx context.Context, entity, id, action string, data map[string]any) (json.RawMessage, error) {
+	if data == nil {
+		data = map[string]any{}
+	}
+	body := map[string]any{
+		"entityType": entity,
+		"action":     action,
+		"id":         id,
+		"data":       data,
+	}
+	resp, err := c.request(ctx, MethodPost, "Action", body, nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(resp.Body), nil
+}
+
+// ConvertLead converts a lead into the records given by entity type, e.g.
+// {"Account": {...}, "Contact": {...}, "Opportunity": {...}}, as the Convert action
+// of the UI, and returns the converted lead. Use GetLeadConvertAttributes for the
+// attributes the UI would prefill.
+func (c *Client) ConvertLead(ctx context.Context, leadID string, records map[string]map[string]any) (map[string]any, error) {
+	raw, err := c.Action(ctx, "Lead", leadID, "convert", map[string]any{"records": records})
+	if err != nil {
+		return nil, err
+	}
+	var lead map[string]any
+	if err := json.Unmarshal(raw, &lead); err != nil {
+		return nil, &EspoError{Message: "failed to parse converted lead", Cause: err}
+	}
+	return lead, nil
+}
+
+// GetLeadConvertAttributes returns the attributes the UI prefills for each entity
+// type when converting a lead.
+func (c *Client) GetLeadConvertAttributes(ctx context.Context, leadID string) (map[string]any, error) {
+	raw, err := c.Action(ctx, "Lead", leadID, "getConvertAttributes", nil)
+	if err != nil {
+		return nil, err
+	}
+	var attributes map[string]any
+	if err := json.Unmarshal(raw, &attributes); err != nil {
+		return nil, &EspoError{Message: "failed to parse convert attributes", Cause: err}
+	}
+	return attributes, nil
+}
+
+// MergeRecords merges the source records into the target record, which gets the
+// given attributes (nil keeps its own). Links of the sources are moved to the target
+// and the sources are deleted.
+func (c *Client) MergeRecords(ctx context.Context, entity, targetID string, sourceIDs []string, attributes map[string]any) error {
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	_, err := c.Action(ctx, entity, "", "merge", map[string]any{
+		"targetId":   targetID,
+		"sourceIds":  sourceIDs,
+		"attributes": attributes,
+	})
+	return err
+}
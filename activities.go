@@ -0,0 +1,107 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// Activity is a meeting, call, task, email or other activity related to a record,
+// as listed by the Activities endpoints. Common attributes have fields; all of them
+// are in Raw.
+type Activity struct {
+	ID         string `json:"id"`
+	EntityType string `json:"_scope"` // "Meeting", "Call", "Task", "Email", ...
+	Name       string `json:"name"`
+	Status     string `json:"status,omitempty"`
+	DateStart  string `json:"dateStart,omitempty"` // UTC, see StartTime
+	DateEnd    string `json:"dateEnd,omitempty"`
+	// DateStartDate and DateEndDate are set instead of DateStart and DateEnd for
+	// all-day activities.
+	DateStartDate    string `json:"dateStartDate,omitempty"`
+	DateEndDate      string `json:"dateEndDate,omitempty"`
+	ParentType       string `json:"parentType,omitempty"`
+	ParentID         string `json:"parentId,omitempty"`
+	ParentName       string `json:"parentName,omitempty"`
+	AssignedUserID   string `json:"assignedUserId,omitempty"`
+	AssignedUserName string `json:"assignedUserName,omitempty"`
+	CreatedAt        string `json:"createdAt,omitempty"`
+
+	Raw map[string]any `json:"-"`
+}
+
+// UnmarshalJSON decodes the common attributes and keeps all of them in Raw.
+func (a *Activity) UnmarshalJSON(data []byte) error {
+	type plain Activity
+	if err := json.Unmarshal(data, (*plain)(a)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &a.Raw)
+}
+
+// IsAllDay reports whether the activity lasts whole days.
+func (a *Activity) IsAllDay() bool {
+	return a.DateStart == "" && a.DateStartDate != ""
+}
+
+// StartTime parses DateStart, or DateStartDate in loc for an all-day activity.
+func (a *Activity) StartTime(loc *time.Location) (time.Time, error) {
+	if a.IsAllDay() {
+		return ParseDate(a.DateStartDate, loc)
+	}
+	return ParseDateTime(a.DateStart, loc)
+}
+
+// ActivityParams filters and pages GetActivities and GetHistory.
+type ActivityParams struct {
+	// EntityType limits the list to one activity type, e.g. "Meeting"; all if empty.
+	EntityType string
+	Offset     int
+	MaxSize    int // defaultPageSize if zero
+}
+
+// ActivityList is one page of activities.
+type ActivityList struct {
+	Total int
+	List  []Activity
+}
+
+// GetActivities returns the upcoming activities of a record: the meetings and
+// calls that are planned and the tasks that are not completed, soonest first.
+func (c *Client) GetActivities(ctx context.Context, entity, id string, params *ActivityParams) (*ActivityList, error) {
+	return c.listActivities(ctx, entity, id, "activities", params)
+}
+
+// GetHistory returns the past activities of a record: held or not held meetings
+// and calls, and archived or sent emails, most recent first.
+func (c *Client) GetHistory(ctx context.Context, entity, id string, params *ActivityParams) (*ActivityList, error) {
+	return c.listActivities(ctx, entity, id, "history", params)
+}
+
+// listActivities fetches a page of Activities/{entity}/{id}/{kind}.
+func (c *Client) listActivities(ctx context.Context, entity, id, kind string, params *ActivityParams) (*ActivityList, error) {
+	if params == nil {
+		params = &ActivityParams{}
+	}
+	query := url.Values{}
+	if params.EntityType != "" {
+		query.Set("entityType", params.EntityType)
+	}
+	size := params.MaxSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	path := "Activities/" + url.PathEscape(entity) + "/" + url.PathEscape(id) + "/" + kind
+	page, err := c.listPage(ctx, path, query, params.Offset, size)
+	if err != nil {
+		return nil, err
+	}
+	list := &ActivityList{Total: page.Total, List: make([]Activity, len(page.List))}
+	for i, raw := range page.List {
+		if err := json.Unmarshal(raw, &list.List[i]); err != nil {
+			return nil, &EspoError{Message: "failed to parse activity", Cause: err}
+		}
+	}
+	return list, nil
+}
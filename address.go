@@ -0,0 +1,126 @@
+package espoclient
+
+import (
+	"context"
+	"strings"
+)
+
+// Names of the built-in address fields.
+const (
+	FieldAddress         = "address" // Contact, Lead
+	FieldBillingAddress  = "billingAddress"
+	FieldShippingAddress = "shippingAddress"
+)
+
+// Address is the composite value of an EspoCRM address field. EspoCRM stores it
+// as separate attributes: {field}Street, {field}City, {field}State,
+// {field}Country and {field}PostalCode.
+type Address struct {
+	Street     string
+	City       string
+	State      string
+	Country    string
+	PostalCode string
+}
+
+// addressParts maps attribute suffixes to Address members.
+func (a *Address) addressParts() map[string]*string {
+	return map[string]*string{
+		"Street":     &a.Street,
+		"City":       &a.City,
+		"State":      &a.State,
+		"Country":    &a.Country,
+		"PostalCode": &a.PostalCode,
+	}
+}
+
+// SplitAddress reads the address field (e.g. FieldBillingAddress) from a record.
+// Missing or non-string attributes are left empty.
+func SplitAddress(record map[string]any, field string) Address {
+	var a Address
+	for suffix, part := range a.addressParts() {
+		if v, ok := record[field+suffix].(string); ok {
+			*part = v
+		}
+	}
+	return a
+}
+
+// MergeInto writes the address into record as the attributes of field. It
+// replaces the whole address: blank parts are written too and clear the stored
+// values on update.
+func (a Address) MergeInto(record map[string]any, field string) {
+	for suffix, part := range a.addressParts() {
+		record[field+suffix] = *part
+	}
+}
+
+// IsEmpty reports whether all parts of the address are blank.
+func (a Address) IsEmpty() bool {
+	for _, part := range a.addressParts() {
+		if strings.TrimSpace(*part) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the address on a single line, skipping blank parts.
+func (a Address) String() string {
+	var parts []string
+	for _, part := range []string{a.Street, a.City, a.State, a.PostalCode, a.Country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// addressAttributes counts the attributes of the address field present in record.
+func addressAttributes(record map[string]any, field string) int {
+	n := 0
+	for suffix := range (&Address{}).addressParts() {
+		if _, ok := record[field+suffix]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Geocoder resolves an address to coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, address Address) (lat, lng float64, err error)
+}
+
+// GeocodingHook returns a RecordHook that geocodes the given address field and
+// stores the coordinates in latField and lngField (typically custom float fields).
+// The geocoder is called for creates with a non-empty address and for updates
+// that carry every part of the address, as written by Address.MergeInto. Updates
+// of only some parts are left alone, since the stored parts are unknown to the
+// hook; the coordinates are cleared when the address is blanked.
+// A geocoder error aborts the request; wrap the Geocoder to ignore failures instead.
+func GeocodingHook(g Geocoder, field, latField, lngField string) RecordHook {
+	return func(ctx context.Context, op Operation, _ string, record map[string]any) error {
+		switch n := addressAttributes(record, field); {
+		case n == 0:
+			return nil
+		case op == OperationUpdate && n < len((&Address{}).addressParts()):
+			return nil
+		}
+		address := SplitAddress(record, field)
+		if address.IsEmpty() {
+			if op == OperationUpdate {
+				record[latField] = nil
+				record[lngField] = nil
+			}
+			return nil
+		}
+		lat, lng, err := g.Geocode(ctx, address)
+		if err != nil {
+			return err
+		}
+		record[latField] = lat
+		record[lngField] = lng
+		return nil
+	}
+}
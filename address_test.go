@@ -0,0 +1,63 @@
+package espoclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+type cityGeocoder struct{}
+
+func (cityGeocoder) Geocode(ctx context.Context, a espoclient.Address) (lat, lng float64, err error) {
+	return 48.85, 2.35, nil
+}
+
+func TestGeocodingHook(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.AddRecordHook(espoclient.GeocodingHook(cityGeocoder{}, espoclient.FieldBillingAddress, "lat", "lng"))
+
+	full := map[string]any{}
+	espoclient.Address{Street: "1 Rue de Rivoli", City: "Paris", Country: "France"}.MergeInto(full, espoclient.FieldBillingAddress)
+	blank := map[string]any{}
+	espoclient.Address{}.MergeInto(blank, espoclient.FieldBillingAddress)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		record map[string]any
+		want   string
+	}{
+		{"create", espoclient.MethodPost, "Account", map[string]any{"billingAddressCity": "Paris"},
+			`{"billingAddressCity":"Paris","lat":48.85,"lng":2.35}`},
+		{"partial update", espoclient.MethodPatch, "Account/1", map[string]any{"billingAddressCity": "Paris"},
+			`{"billingAddressCity":"Paris"}`},
+		{"full update", espoclient.MethodPut, "Account/1", full,
+			`{"billingAddressCity":"Paris","billingAddressCountry":"France","billingAddressPostalCode":"","billingAddressState":"","billingAddressStreet":"1 Rue de Rivoli","lat":48.85,"lng":2.35}`},
+		{"blanked", espoclient.MethodPut, "Account/1", blank,
+			`{"billingAddressCity":"","billingAddressCountry":"","billingAddressPostalCode":"","billingAddressState":"","billingAddressStreet":"","lat":null,"lng":null}`},
+	}
+	for _, tt := range tests {
+		if _, err := client.Request(tt.method, tt.path, tt.record, nil); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if body != tt.want {
+			t.Errorf("%s: sent %s, want %s", tt.name, body, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package espoclient
+
+import (
+	"net/url"
+	"strings"
+)
+
+// APIVersion is a version of the EspoCRM REST API.
+type APIVersion string
+
+// APIVersion1 is the current API version, used by default.
+const APIVersion1 APIVersion = "v1"
+
+// Path returns the API path of the version, e.g. "/api/v1/".
+func (v APIVersion) Path() string {
+	return "/api/" + string(v) + "/"
+}
+
+// PortalPath returns the API path for accessing a portal as its users do,
+// e.g. "/api/v1/portal-access/{portalID}/".
+func (v APIVersion) PortalPath(portalID string) string {
+	return v.Path() + "portal-access/" + url.PathEscape(portalID) + "/"
+}
+
+// APIPath returns the path of the API relative to the base URL.
+func (c *Client) APIPath() string {
+	return c.apiPath
+}
+
+// SetAPIPath sets the path of the API relative to the base URL ("/api/v1/" by
+// default), e.g. for instances served behind a path-rewriting proxy.
+func (c *Client) SetAPIPath(path string) *Client {
+	c.apiPath = "/"
+	if trimmed := strings.Trim(path, "/"); trimmed != "" {
+		c.apiPath += trimmed + "/"
+	}
+	return c
+}
+
+// SetAPIVersion targets another version of the API.
+func (c *Client) SetAPIVersion(version APIVersion) *Client {
+	return c.SetAPIPath(version.Path())
+}
+
+// SetPortal sends requests through the portal access API of a portal, so records
+// are filtered by the portal's roles. Use SetAPIPath with APIVersion.PortalPath
+// for other API versions.
+func (c *Client) SetPortal(portalID string) *Client {
+	return c.SetAPIPath(APIVersion1.PortalPath(portalID))
+}
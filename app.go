@@ -0,0 +1,89 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// UserInfo describes the authenticated user.
+type UserInfo struct {
+	ID            string `json:"id"`
+	UserName      string `json:"userName"`
+	Name          string `json:"name"`
+	Type          string `json:"type"` // "regular", "admin", "api", "portal", ...
+	IsActive      bool   `json:"isActive"`
+	EmailAddress  string `json:"emailAddress,omitempty"`
+	DefaultTeamID string `json:"defaultTeamId,omitempty"`
+}
+
+// IsAdmin reports whether the user has administrator rights.
+func (u *UserInfo) IsAdmin() bool {
+	return u.Type == "admin" || u.Type == "super-admin"
+}
+
+// AppUser is the response of App/user: the authenticated user with their access
+// rights, preferences and the settings visible to them.
+type AppUser struct {
+	User        UserInfo       `json:"user"`
+	ACL         ACL            `json:"acl"`
+	Preferences map[string]any `json:"preferences"`
+	Settings    map[string]any `json:"settings"`
+	Token       string         `json:"token,omitempty"`
+}
+
+// Settings holds the system settings visible to the authenticated user. Common
+// settings have fields; all of them are in Raw.
+type Settings struct {
+	Version         string   `json:"version"`
+	SiteURL         string   `json:"siteUrl"`
+	TimeZone        string   `json:"timeZone"`
+	Language        string   `json:"language"`
+	DateFormat      string   `json:"dateFormat"`
+	TimeFormat      string   `json:"timeFormat"`
+	WeekStart       int      `json:"weekStart"`
+	DefaultCurrency string   `json:"defaultCurrency"`
+	CurrencyList    []string `json:"currencyList"`
+	// CurrencyDecimalPlaces is the number of decimals of currency amounts; nil if not set.
+	CurrencyDecimalPlaces *int `json:"currencyDecimalPlaces"`
+	RecordsPerPage        int  `json:"recordsPerPage"`
+
+	// Outbound email settings, only visible to administrators.
+	OutboundEmailFromName    string `json:"outboundEmailFromName,omitempty"`
+	OutboundEmailFromAddress string `json:"outboundEmailFromAddress,omitempty"`
+	SMTPServer               string `json:"smtpServer,omitempty"`
+	SMTPPort                 int    `json:"smtpPort,omitempty"`
+	SMTPAuth                 bool   `json:"smtpAuth,omitempty"`
+	SMTPSecurity             string `json:"smtpSecurity,omitempty"` // "", "SSL" or "TLS"
+	SMTPUsername             string `json:"smtpUsername,omitempty"`
+
+	Raw map[string]any `json:"-"`
+}
+
+// UnmarshalJSON decodes the known settings and keeps all of them in Raw.
+func (s *Settings) UnmarshalJSON(data []byte) error {
+	type plain Settings
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.Raw)
+}
+
+// GetAppUser returns the authenticated user (App/user). As it fails with a 401
+// ResponseError for bad credentials, it is a cheap way to validate them at startup.
+func (c *Client) GetAppUser(ctx context.Context) (*AppUser, error) {
+	return getTyped[AppUser](ctx, c, "App/user")
+}
+
+// GetSettings returns the system settings visible to the authenticated user.
+func (c *Client) GetSettings(ctx context.Context) (*Settings, error) {
+	return getTyped[Settings](ctx, c, "Settings")
+}
+
+// getTyped fetches path and decodes the response into a new T.
+func getTyped[T any](ctx context.Context, c *Client, path string) (*T, error) {
+	v, err := GetAs[T](ctx, c, path)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
@@ -0,0 +1,82 @@
+package espoclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestChunkedUploadFailureDeletesAttachment(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		chunks := len(requests) - 1
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/Attachment":
+			w.Write([]byte(`{"id":"a/1"}`))
+		case r.Method == http.MethodPost && chunks > 1:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.Write([]byte(`true`))
+		}
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := strings.NewReader(strings.Repeat("x", 10))
+	_, err = client.UploadAttachment(context.Background(), file, espoclient.AttachmentMeta{Name: "a.txt", ChunkSize: 4})
+	if err == nil {
+		t.Fatal("upload succeeded, want the chunk error")
+	}
+	want := []string{
+		"POST /api/v1/Attachment",
+		"POST /api/v1/Attachment/chunk/a%2F1",
+		"POST /api/v1/Attachment/chunk/a%2F1",
+		"DELETE /api/v1/Attachment/a%2F1",
+	}
+	if !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
+
+func TestUploadAttachmentEscapesType(t *testing.T) {
+	var file string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ File string }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		file = body.File
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"a1"}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const mimeType = `text/plain; name="a\b"`
+	meta := espoclient.AttachmentMeta{Name: "a.txt", Type: mimeType}
+	if _, err := client.UploadAttachment(context.Background(), strings.NewReader("hi"), meta); err != nil {
+		t.Fatal(err)
+	}
+	if want := "data:" + mimeType + ";base64,aGk="; file != want {
+		t.Errorf("file = %q, want %q", file, want)
+	}
+}
@@ -0,0 +1,77 @@
+package espoclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// The expected headers were computed independently: base64(apiKey + ":" +
+// base64(HMAC-SHA256(secretKey, method + " " + path))).
+func TestHMACSignature(t *testing.T) {
+	const apiKey, secretKey = "7a1d5c2e9b", "f3b8c4d2e1a0"
+	tests := []struct {
+		name   string
+		base   string // path of the base URL
+		method string
+		path   string
+		want   string
+	}{
+		{
+			name:   "record",
+			method: espoclient.MethodGet,
+			path:   "Lead/5f1a2b3c",
+			want:   "N2ExZDVjMmU5YjptS3FmbXh1Q2xickI5OWsvenZKdTBpQm9HeW5oSVZDS2REeVFFK1lnQUpzPQ==",
+		},
+		{
+			name:   "query string is not signed",
+			method: espoclient.MethodGet,
+			path:   "Lead?select=name&maxSize=5",
+			want:   "N2ExZDVjMmU5Yjp4RXFLMWNmL1RDMkl1UXgzZnpRald0VlhLV0pWWk9HWE1LYXpaR05xVEY4PQ==",
+		},
+		{
+			name:   "escaped slash",
+			method: espoclient.MethodPost,
+			path:   "Account/a%2Fb/opportunities",
+			want:   "N2ExZDVjMmU5Yjp1VHJ1SnRscDE3MDlnSzNLNWZuM296TUkrSnVMV3NYeEFyZGZEOTZFUW9ZPQ==",
+		},
+		{
+			name:   "escaped space",
+			method: espoclient.MethodPut,
+			path:   "Contact/c%20d",
+			want:   "N2ExZDVjMmU5Yjo0Z0xoTlpYc0NkZFh4V0NFcUM4SUVMNTZyVzNMZ013bjF4T2RFOUJpRWZ3PQ==",
+		},
+		{
+			name:   "base URL with a path",
+			base:   "/crm/",
+			method: espoclient.MethodDelete,
+			path:   "Lead/5f1a2b3c/teams",
+			want:   "N2ExZDVjMmU5Yjo3NjZsL3FONWhsRjFib2pHYWhVTVVkTXZCaHZBQzVSaC9VY1V0dnpaRG00PQ==",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("X-Hmac-Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte("{}"))
+			}))
+			defer srv.Close()
+			client, err := espoclient.NewClient(srv.URL+tt.base, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.SetApiKey(apiKey).SetSecretKey(secretKey)
+
+			if _, err := client.Request(tt.method, tt.path, nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("X-Hmac-Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package espoclient
+
+import (
+	"context"
+	"net/url"
+	"sync"
+)
+
+// defaultGetManyConcurrency is used by GetMany when concurrency is not positive.
+const defaultGetManyConcurrency = 4
+
+// getRecord fetches {entity}/{id} and decodes the record into v.
+func (c *Client) getRecord(ctx context.Context, entity, id string, v any) error {
+	resp, err := c.request(ctx, MethodGet, entity+"/"+url.PathEscape(id), nil, nil)
+	if err != nil {
+		return err
+	}
+	if err := resp.GetParsedBody(v); err != nil {
+		return &EspoError{Message: "failed to parse record", Cause: err}
+	}
+	return nil
+}
+
+// GetMany fetches records by ID with at most concurrency requests in flight.
+// The results are in the same order as ids; a record that could not be fetched has
+// its error in Result.Err while the others are still returned. Once ctx is cancelled,
+// the remaining IDs fail with the context error.
+func GetMany[T any](ctx context.Context, c *Client, entity string, ids []string, concurrency int) []Result[T] {
+	if concurrency <= 0 {
+		concurrency = defaultGetManyConcurrency
+	}
+	results := make([]Result[T], len(ids))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Err = c.getRecord(ctx, entity, ids[i], &results[i].Value)
+			}
+		}()
+	}
+	for i := range ids {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
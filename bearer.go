@@ -0,0 +1,36 @@
+package espoclient
+
+import (
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// SetBearerToken authenticates with a fixed OAuth 2.0 / OIDC access token sent as
+// "Authorization: Bearer". Use SetTokenSource for tokens that expire.
+func (c *Client) SetBearerToken(token string) *Client {
+	return c.SetTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token, TokenType: "Bearer"}))
+}
+
+// SetTokenSource authenticates with access tokens from src, e.g. the TokenSource
+// of an oauth2.Config or clientcredentials.Config. Tokens are reused until they
+// expire and then refreshed by src.
+func (c *Client) SetTokenSource(src oauth2.TokenSource) *Client {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.tokenSource = oauth2.ReuseTokenSource(nil, src)
+	c.username, c.password = nil, nil // Clear other auth methods
+	c.apiKey, c.secretKey = nil, nil
+	c.tokenAuth = nil
+	return c
+}
+
+// applyBearerToken sets the Authorization header from a token source.
+func applyBearerToken(req *http.Request, src oauth2.TokenSource) error {
+	token, err := src.Token()
+	if err != nil {
+		return &EspoError{Message: "failed to obtain access token", Cause: err}
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
@@ -0,0 +1,134 @@
+package espoclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ErrBodyTooLarge is the cause of errors reported when a request body exceeds the
+// limit set with SetMaxRequestBodySize.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// Body is a request body streamed from its source rather than loaded into memory.
+// Pass it, or an *os.File, as the data argument of Request to upload large
+// payloads with flat memory use.
+type Body struct {
+	// Open returns a reader positioned at the start of the body. It is called for
+	// the first attempt and again for every retry.
+	Open func() (io.ReadCloser, error)
+	// Size is the body length in bytes, or -1 if unknown (chunked transfer encoding).
+	Size int64
+	// ContentType, if set, is sent unless overridden by the headers.
+	ContentType string
+}
+
+// FileBody returns a Body that streams f from its current offset to the end. The
+// file is read through ReadAt, so it can be re-sent on retry; it is not closed.
+// f must be a regular file.
+func FileBody(f *os.File) (*Body, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", f.Name())
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	size := max(info.Size()-offset, 0)
+	return &Body{
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(io.NewSectionReader(f, offset, size)), nil
+		},
+		Size: size,
+	}, nil
+}
+
+// readerLen returns the remaining length of readers that report it, or -1.
+func readerLen(r io.Reader) int64 {
+	if l, ok := r.(interface{ Len() int }); ok {
+		return int64(l.Len())
+	}
+	return -1
+}
+
+// SetMaxRequestBodySize rejects request bodies larger than limit bytes before they
+// are sent. Bodies of unknown length fail once they exceed the limit while streaming.
+// A limit of zero or less (the default) disables the check.
+func (c *Client) SetMaxRequestBodySize(limit int64) *Client {
+	c.maxBodySize = limit
+	return c
+}
+
+// limitBody enforces the maximum body size on req: bodies of known length are
+// rejected up front, others are wrapped to fail while streaming.
+func (c *Client) limitBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	limit := c.maxBodySize
+	if req.ContentLength > limit {
+		req.Body.Close()
+		return bodyTooLarge(req.URL.Path, req.ContentLength, limit)
+	}
+	if req.ContentLength > 0 {
+		return nil
+	}
+
+	path := req.URL.Path
+	req.Body = &limitedBody{ReadCloser: req.Body, remaining: limit, limit: limit, path: path}
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return &limitedBody{ReadCloser: body, remaining: limit, limit: limit, path: path}, nil
+		}
+	}
+	return nil
+}
+
+// bodyTooLarge reports a body exceeding the limit, pointing at the way of sending
+// the data of the request path in smaller parts.
+func bodyTooLarge(path string, size, limit int64) error {
+	msg := fmt.Sprintf("request body exceeds the limit of %d bytes", limit)
+	if size >= 0 {
+		msg = fmt.Sprintf("request body of %d bytes exceeds the limit of %d bytes", size, limit)
+	}
+	switch {
+	case strings.Contains(path, "/Attachment/chunk/"):
+		// Chunks are base64-encoded, growing by a third.
+		msg += "; lower AttachmentMeta.ChunkSize below three quarters of the limit"
+	case strings.HasSuffix(path, "/Attachment"):
+		msg += "; upload large files in chunks with AttachmentMeta.ChunkSize"
+	case strings.HasSuffix(path, "/Import/file"):
+		msg += "; split the data into several import files"
+	default:
+		msg += "; upload files as attachments and bulk data through the import API"
+	}
+	return &EspoError{Message: msg, Cause: ErrBodyTooLarge}
+}
+
+// limitedBody fails with ErrBodyTooLarge once more than limit bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+	path      string // request path, for the error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n, bodyTooLarge(b.path, -1, b.limit)
+	}
+	return n, err
+}
@@ -0,0 +1,60 @@
+package espoclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestBodyTooLargeHint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"a1"}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetMaxRequestBodySize(200)
+	ctx := context.Background()
+	file := strings.Repeat("x", 300)
+
+	tests := []struct {
+		name string
+		call func() error
+		hint string
+	}{
+		{"attachment", func() error {
+			_, err := client.UploadAttachment(ctx, strings.NewReader(file), espoclient.AttachmentMeta{Name: "a.txt"})
+			return err
+		}, "AttachmentMeta.ChunkSize"},
+		{"chunk", func() error {
+			_, err := client.UploadAttachment(ctx, strings.NewReader(file), espoclient.AttachmentMeta{Name: "a.txt", ChunkSize: 180})
+			return err
+		}, "lower AttachmentMeta.ChunkSize"},
+		{"import file", func() error {
+			_, err := client.UploadImportFile(ctx, strings.NewReader(file))
+			return err
+		}, "several import files"},
+		{"record", func() error {
+			_, err := client.CreateEntity(ctx, "Note", map[string]any{"post": file})
+			return err
+		}, "import API"},
+	}
+	for _, tt := range tests {
+		err := tt.call()
+		if !errors.Is(err, espoclient.ErrBodyTooLarge) {
+			t.Errorf("%s: err = %v, want ErrBodyTooLarge", tt.name, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.hint) {
+			t.Errorf("%s: err = %v, want a hint containing %q", tt.name, err, tt.hint)
+		}
+	}
+}
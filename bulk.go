@@ -0,0 +1,150 @@
+package espoclient
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBulkBatchSize is used by BulkCreate and BulkUpsert when BatchSize is not positive.
+const defaultBulkBatchSize = 50
+
+// BulkOptions configures BulkCreate and BulkUpsert.
+type BulkOptions struct {
+	// BatchSize is the number of records a worker processes in a row; 50 if zero.
+	BatchSize int
+	// Concurrency is the number of batches processed at once; 4 if zero.
+	Concurrency int
+	// RateLimit limits the rate of records written, on top of the client's own limit.
+	RateLimit RateLimit
+	// SkipDuplicateCheck creates records even if the server's duplicate check finds matches.
+	SkipDuplicateCheck bool
+	// OnBatch, if set, is called after each batch with the number of records
+	// processed so far. It may be called concurrently.
+	OnBatch func(done, total int)
+}
+
+// BulkRecordResult is the outcome of writing one record.
+type BulkRecordResult struct {
+	Index   int    // position of the record in the input
+	ID      string // ID of the created or updated record
+	Created bool   // false if an existing record was updated
+	Err     error
+}
+
+// BulkReport summarizes a bulk write.
+type BulkReport struct {
+	Created int
+	Updated int
+	Failed  int
+	// Results holds one result per input record, in input order.
+	Results []BulkRecordResult
+}
+
+// Errors returns the results of the records that could not be written.
+func (r *BulkReport) Errors() []BulkRecordResult {
+	var failed []BulkRecordResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// BulkCreate creates records in batches processed by a pool of workers. A record
+// that fails does not stop the others; its error is in the report. Once ctx is
+// cancelled, the remaining records fail with the context error.
+func (c *Client) BulkCreate(ctx context.Context, entity string, records []map[string]any, opts BulkOptions) *BulkReport {
+	return c.bulkWrite(ctx, records, opts, func(ctx context.Context, record map[string]any) (string, bool, error) {
+		stored, err := c.CreateEntity(ctx, entity, record)
+		if err != nil {
+			return "", false, err
+		}
+		id, _ := stored["id"].(string)
+		return id, true, nil
+	})
+}
+
+// BulkUpsert is like BulkCreate, but updates the existing record whose matchField
+// (e.g. an external ID field) has the same value instead of creating a new one.
+func (c *Client) BulkUpsert(ctx context.Context, entity, matchField string, records []map[string]any, opts BulkOptions) *BulkReport {
+	return c.bulkWrite(ctx, records, opts, func(ctx context.Context, record map[string]any) (string, bool, error) {
+		stored, created, err := c.upsert(ctx, entity, matchField, record, UpsertOptions{})
+		if err != nil {
+			return "", false, err
+		}
+		id, _ := stored["id"].(string)
+		return id, created, nil
+	})
+}
+
+// bulkWrite runs write for every record and collects the results.
+func (c *Client) bulkWrite(ctx context.Context, records []map[string]any, opts BulkOptions,
+	write func(context.Context, map[string]any) (string, bool, error)) *BulkReport {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBulkBatchSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultGetManyConcurrency
+	}
+	if opts.SkipDuplicateCheck {
+		ctx = ContextWithRequestOptions(ctx, SkipDuplicateCheck())
+	}
+	limiter := newRateLimiter(opts.RateLimit)
+
+	report := &BulkReport{Results: make([]BulkRecordResult, len(records))}
+	var mu sync.Mutex
+	done := 0
+
+	batches := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, (len(records)+batchSize-1)/batchSize) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range batches {
+				end := min(start+batchSize, len(records))
+				for i := start; i < end; i++ {
+					result := &report.Results[i]
+					result.Index = i
+					if result.Err = ctx.Err(); result.Err != nil {
+						continue
+					}
+					if limiter != nil {
+						if result.Err = limiter.wait(ctx, c.clock); result.Err != nil {
+							continue
+						}
+					}
+					result.ID, result.Created, result.Err = write(ctx, records[i])
+				}
+
+				if opts.OnBatch != nil {
+					mu.Lock()
+					done += end - start
+					n := done
+					mu.Unlock()
+					opts.OnBatch(n, len(records))
+				}
+			}
+		}()
+	}
+	for start := 0; start < len(records); start += batchSize {
+		batches <- start
+	}
+	close(batches)
+	wg.Wait()
+
+	for _, result := range report.Results {
+		switch {
+		case result.Err != nil:
+			report.Failed++
+		case result.Created:
+			report.Created++
+		default:
+			report.Updated++
+		}
+	}
+	return report
+}
@@ -0,0 +1,270 @@
+package espoclient
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached GET response.
+type CacheEntry struct {
+	Entity       string // entity type the request acts on, used for invalidation
+	Response     *Response
+	ETag         string
+	LastModified string
+	// Expires is when the entry must be revalidated with the server.
+	Expires time.Time
+}
+
+// CacheStore stores cached GET responses. Implementations must be safe for
+// concurrent use; MemoryCache is the default one.
+type CacheStore interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	// DeleteEntity removes the entries of an entity type.
+	DeleteEntity(entity string)
+	// Clear removes all entries.
+	Clear()
+}
+
+// responseCache is the cache configuration of a client.
+type responseCache struct {
+	store CacheStore
+	ttl   time.Duration
+}
+
+// SetCache caches the responses of GET requests in store (a MemoryCache of 1000
+// entries if nil). Responses are reused for the max-age of their Cache-Control
+// header, or ttl if the server sends none; an expired entry with an ETag or
+// Last-Modified header is revalidated with a conditional request. Entries are keyed
+// by URL and request headers, including the credentials, so clients derived with
+// WithAuth or WithHeaders share the store safely.
+//
+// A successful write (POST, PUT, PATCH, DELETE) invalidates the entries of the
+// entity type it acts on: the one the path starts with, e.g. "Lead" for
+// "Lead/{id}", or the entityType of mass action, import and record action requests.
+// Use InvalidateCache for changes made by other clients. NoCache bypasses the cache for one request.
+func (c *Client) SetCache(store CacheStore, ttl time.Duration) *Client {
+	if store == nil {
+		store = NewMemoryCache(1000)
+	}
+	c.cache = &responseCache{store: store, ttl: ttl}
+	return c
+}
+
+// DisableCache stops caching responses.
+func (c *Client) DisableCache() *Client {
+	c.cache = nil
+	return c
+}
+
+// InvalidateCache removes the cached responses of an entity type, or all cached
+// responses if entity is empty.
+func (c *Client) InvalidateCache(entity string) {
+	if c.cache == nil {
+		return
+	}
+	if entity == "" {
+		c.cache.store.Clear()
+		return
+	}
+	c.cache.store.DeleteEntity(entity)
+}
+
+// NoCache sends the request to the server even if a cached response exists; the
+// response still updates the cache.
+func NoCache() RequestOption {
+	return func(o *requestOptions) {
+		o.noCache = true
+	}
+}
+
+// cachedSend sends req, which acts on entity, through the cache: GET requests are
+// answered from it or revalidated, writes invalidate the entries of the entity.
+func (c *Client) cachedSend(req *http.Request, entity string, options *requestOptions, send func(*http.Request) (*Response, error)) (*Response, error) {
+	cache := c.cache
+
+	if req.Method != MethodGet {
+		resp, err := send(req)
+		if err == nil && isWriteMethod(req.Method) && entity != "" {
+			cache.store.DeleteEntity(entity)
+		}
+		return resp, err
+	}
+	if options.stream != nil || options.output != nil {
+		return send(req)
+	}
+
+	key := cacheKey(req)
+	now := c.clock.Now()
+	entry, ok := cache.store.Get(key)
+	if ok && !options.noCache && now.Before(entry.Expires) {
+		return entry.copyResponse(), nil
+	}
+	if ok {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := send(req)
+	var respErr *ResponseError
+	if ok && errors.As(err, &respErr) && respErr.Response.StatusCode == http.StatusNotModified {
+		if expires, cacheable := cache.expires(respErr.Response.Headers, now); cacheable {
+			revalidated := *entry
+			revalidated.Expires = expires
+			cache.store.Set(key, &revalidated)
+		}
+		return entry.copyResponse(), nil
+	}
+	if err == nil {
+		if expires, cacheable := cache.expires(resp.Headers, now); cacheable {
+			cache.store.Set(key, &CacheEntry{
+				Entity:       entity,
+				Response:     resp,
+				ETag:         resp.Headers.Get("ETag"),
+				LastModified: resp.Headers.Get("Last-Modified"),
+				Expires:      expires,
+			})
+			resp = (&CacheEntry{Response: resp}).copyResponse()
+		}
+	}
+	return resp, err
+}
+
+// expires returns when a response with the given headers must be revalidated, and
+// whether it may be cached at all.
+func (rc *responseCache) expires(h http.Header, now time.Time) (time.Time, bool) {
+	validated := h.Get("ETag") != "" || h.Get("Last-Modified") != ""
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(strings.ToLower(directive)), "=")
+		switch name {
+		case "no-store":
+			return time.Time{}, false
+		case "no-cache":
+			return now, validated
+		case "max-age":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				return now.Add(time.Duration(seconds) * time.Second), seconds > 0 || validated
+			}
+		}
+	}
+	return now.Add(rc.ttl), rc.ttl > 0 || validated
+}
+
+// copyResponse returns a copy of the cached response that the caller may modify.
+func (e *CacheEntry) copyResponse() *Response {
+	resp := *e.Response
+	resp.Headers = resp.Headers.Clone()
+	resp.Body = bytes.Clone(resp.Body)
+	return &resp
+}
+
+// isWriteMethod reports whether method changes data on the server, and so
+// invalidates the cache; HEAD and OPTIONS do not.
+func isWriteMethod(method string) bool {
+	switch method {
+	case MethodPost, MethodPut, MethodPatch, MethodDelete:
+		return true
+	}
+	return false
+}
+
+// cacheKey identifies a GET request by URL and headers, which include the
+// credentials and the headers of the client and the call.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	h.Write([]byte(req.URL.String()))
+	for _, name := range slices.Sorted(maps.Keys(req.Header)) {
+		switch name {
+		case "If-None-Match", "If-Modified-Since":
+			continue
+		}
+		for _, value := range req.Header[name] {
+			h.Write([]byte{0})
+			h.Write([]byte(name + ": " + value))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MemoryCache is an in-memory CacheStore that evicts the least recently used
+// entries beyond its capacity.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List // of *memoryCacheItem, most recently used first
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache returns a MemoryCache holding up to capacity entries (unbounded
+// if capacity is not positive).
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{capacity: capacity, entries: map[string]*list.Element{}, lru: list.New()}
+}
+
+// Get implements CacheStore.
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.lru.MoveToFront(elem)
+	return elem.Value.(*memoryCacheItem).entry, true
+}
+
+// Set implements CacheStore.
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryCacheItem).entry = entry
+		m.lru.MoveToFront(elem)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(&memoryCacheItem{key: key, entry: entry})
+	if m.capacity > 0 && m.lru.Len() > m.capacity {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// DeleteEntity implements CacheStore.
+func (m *MemoryCache) DeleteEntity(entity string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, elem := range m.entries {
+		if elem.Value.(*memoryCacheItem).entry.Entity == entity {
+			m.lru.Remove(elem)
+			delete(m.entries, key)
+		}
+	}
+}
+
+// Clear implements CacheStore.
+func (m *MemoryCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.entries)
+	m.lru.Init()
+}
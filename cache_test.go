@@ -0,0 +1,106 @@
+package espoclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestCacheInvalidation(t *testing.T) {
+	var gets atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetCache(nil, time.Minute)
+
+	steps := []struct {
+		method   string
+		wantGets int32 // GET requests seen by the server after the step
+	}{
+		{espoclient.MethodGet, 1},
+		{espoclient.MethodGet, 1},
+		{http.MethodHead, 1},
+		{espoclient.MethodOptions, 1},
+		{espoclient.MethodGet, 1},
+		{espoclient.MethodPatch, 1},
+		{espoclient.MethodGet, 2},
+	}
+	for i, step := range steps {
+		if _, err := client.Request(step.method, "Lead/1", nil, nil); err != nil {
+			t.Fatalf("step %d (%s): %v", i, step.method, err)
+		}
+		if got := gets.Load(); got != step.wantGets {
+			t.Fatalf("step %d (%s): server saw %d GET requests, want %d", i, step.method, got, step.wantGets)
+		}
+	}
+}
+
+func TestCacheKeyedByHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"tenant":%q}`, r.Header.Get("X-Tenant"))
+	}))
+	defer srv.Close()
+	base, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base.SetApiKey("key").SetCache(nil, time.Minute)
+
+	for _, tenant := range []string{"a", "b", "a"} {
+		client := base.WithHeaders(map[string]string{"X-Tenant": tenant})
+		resp, err := client.Request(espoclient.MethodGet, "Settings", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf(`{"tenant":%q}`, tenant); string(resp.Body) != want {
+			t.Errorf("tenant %s got %s", tenant, resp.Body)
+		}
+	}
+}
+
+func TestCacheInvalidatedByMassAction(t *testing.T) {
+	var gets atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":1}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetCache(nil, time.Minute)
+
+	if _, err := client.Request(espoclient.MethodGet, "Lead", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	selection := espoclient.MassSelection{IDs: []string{"1"}}
+	if _, err := client.MassUpdate(context.Background(), "Lead", selection, map[string]any{"status": "Dead"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Request(espoclient.MethodGet, "Lead", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := gets.Load(); got != 2 {
+		t.Errorf("server saw %d GET requests, want the list fetched again after the mass update", got)
+	}
+}
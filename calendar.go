@@ -0,0 +1,129 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CalendarParams selects the events returned by GetCalendar.
+type CalendarParams struct {
+	From, To time.Time
+	// EntityTypes limits the events to these types, e.g. "Meeting" and "Call"; the
+	// calendar's default types if empty.
+	EntityTypes []string
+	// UserID selects the calendar of a user; the API user's if empty.
+	UserID string
+	// TeamIDs selects the events of the users of these teams instead.
+	TeamIDs []string
+}
+
+// GetCalendar returns the events (meetings, calls, tasks, ...) of a user's or
+// teams' calendar that fall between From and To.
+func (c *Client) GetCalendar(ctx context.Context, params CalendarParams) ([]Activity, error) {
+	query := url.Values{
+		"from": {FormatDateTime(params.From)},
+		"to":   {FormatDateTime(params.To)},
+	}
+	if len(params.EntityTypes) > 0 {
+		query.Set("scopeList", strings.Join(params.EntityTypes, ","))
+	}
+	if params.UserID != "" {
+		query.Set("userId", params.UserID)
+	}
+	if len(params.TeamIDs) > 0 {
+		query.Set("teamIdList", strings.Join(params.TeamIDs, ","))
+	}
+	resp, err := c.request(ctx, MethodGet, "Activities", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var events []Activity
+	if err := json.Unmarshal(resp.Body, &events); err != nil {
+		// Some versions wrap the events in a list response.
+		var page struct {
+			List []Activity `json:"list"`
+		}
+		if json.Unmarshal(resp.Body, &page) != nil {
+			return nil, &EspoError{Message: "failed to parse calendar events", Cause: err}
+		}
+		events = page.List
+	}
+	return events, nil
+}
+
+// BusyRange is a period in which a user has an event.
+type BusyRange struct {
+	DateStart  string `json:"dateStart"` // UTC
+	DateEnd    string `json:"dateEnd"`
+	EntityType string `json:"entityType,omitempty"` // of the event, if the server tells
+	ID         string `json:"id,omitempty"`
+}
+
+// Overlaps reports whether the range intersects the period from start to end.
+func (r BusyRange) Overlaps(start, end time.Time) bool {
+	rangeStart, err := ParseDateTime(r.DateStart, nil)
+	if err != nil {
+		return false
+	}
+	rangeEnd, err := ParseDateTime(r.DateEnd, nil)
+	if err != nil {
+		return false
+	}
+	return rangeStart.Before(end) && start.Before(rangeEnd)
+}
+
+// BusyRangeParams selects the busy ranges returned by GetBusyRanges.
+type BusyRangeParams struct {
+	From, To time.Time
+	UserIDs  []string
+	// ExcludeEntityType and ExcludeID leave out an event, typically the one being
+	// rescheduled, so it does not conflict with itself.
+	ExcludeEntityType string
+	ExcludeID         string
+}
+
+// GetBusyRanges returns the periods between From and To in which each user has an
+// event, keyed by user ID, as the UI uses to warn of scheduling conflicts
+// (Timeline/busyRanges, EspoCRM 8 and later).
+func (c *Client) GetBusyRanges(ctx context.Context, params BusyRangeParams) (map[string][]BusyRange, error) {
+	query := url.Values{
+		"from":       {FormatDateTime(params.From)},
+		"to":         {FormatDateTime(params.To)},
+		"userIdList": {strings.Join(params.UserIDs, ",")},
+	}
+	if params.ExcludeEntityType != "" && params.ExcludeID != "" {
+		query.Set("entityType", params.ExcludeEntityType)
+		query.Set("entityId", params.ExcludeID)
+	}
+	resp, err := c.request(ctx, MethodGet, "Timeline/busyRanges", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var ranges map[string][]BusyRange
+	if err := resp.GetParsedBody(&ranges); err != nil {
+		return nil, &EspoError{Message: "failed to parse busy ranges", Cause: err}
+	}
+	return ranges, nil
+}
+
+// IsBusy reports whether any of the users has an event overlapping the period from
+// start to end, and which ones.
+func (c *Client) IsBusy(ctx context.Context, start, end time.Time, userIDs ...string) ([]string, error) {
+	ranges, err := c.GetBusyRanges(ctx, BusyRangeParams{From: start, To: end, UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	var busy []string
+	for _, userID := range userIDs {
+		for _, r := range ranges[userID] {
+			if r.Overlaps(start, end) {
+				busy = append(busy, userID)
+				break
+			}
+		}
+	}
+	return busy, nil
+}
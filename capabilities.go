@@ -0,0 +1,86 @@
+package espoclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Capabilities describes what the authenticated user can do with an entity type,
+// as discovered by Client.Capabilities.
+type Capabilities struct {
+	Entity string
+
+	// Methods lists the HTTP methods from the Allow header of an OPTIONS request.
+	// It is empty when the server or a proxy does not answer OPTIONS.
+	Methods []string
+
+	// Exists is false when the collection endpoint answered 404.
+	Exists bool
+	// List reports whether listing records succeeded.
+	List bool
+
+	// Actions maps ACL actions (create, read, edit, delete, stream) to their level:
+	// "yes", "no", "all", "team", "own". An entity with plain boolean access
+	// reports "yes" or "no" for every action. It is empty when the server refused
+	// to return the ACL of the user.
+	Actions map[string]string
+}
+
+// Can reports whether the ACL allows the action at any level.
+func (c *Capabilities) Can(action string) bool {
+	level, ok := c.Actions[action]
+	return ok && level != "no"
+}
+
+// Capabilities probes the API to discover the allowed methods and ACL actions for
+// an entity type, so callers can degrade gracefully across EspoCRM versions and
+// restricted API users. HTTP errors from the probes are reflected in the result;
+// only transport failures are returned as errors.
+func (c *Client) Capabilities(ctx context.Context, entity string) (*Capabilities, error) {
+	caps := &Capabilities{Entity: entity, Actions: map[string]string{}}
+
+	resp, err := c.request(ctx, MethodOptions, entity, nil, nil)
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		// A 405 still lists the allowed methods.
+		resp, err = respErr.Response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		for _, allow := range resp.Headers.Values("Allow") {
+			for _, method := range strings.Split(allow, ",") {
+				if method = strings.TrimSpace(method); method != "" {
+					caps.Methods = append(caps.Methods, strings.ToUpper(method))
+				}
+			}
+		}
+	}
+
+	probe := url.Values{"maxSize": {"1"}, "select": {"id"}}
+	_, err = c.request(ctx, MethodGet, entity, probe, nil)
+	switch {
+	case err == nil:
+		caps.Exists, caps.List = true, true
+	case errors.As(err, &respErr):
+		caps.Exists = respErr.Response.StatusCode != http.StatusNotFound
+	default:
+		return nil, err
+	}
+
+	acl, err := c.ACL(ctx)
+	if errors.As(err, &respErr) {
+		return caps, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for action, level := range acl.Table[entity] {
+		caps.Actions[action] = string(level)
+	}
+	return caps, nil
+}
@@ -0,0 +1,40 @@
+package espoclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// A user without access to App/user still gets the capabilities found by the
+// other probes.
+func TestCapabilitiesWithoutACLAccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/App/user" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Allow", "GET, POST")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":0,"list":[]}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caps, err := client.Capabilities(context.Background(), "Lead")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caps.Exists || !caps.List || len(caps.Methods) != 2 {
+		t.Errorf("capabilities = %+v, want the probe results", caps)
+	}
+	if len(caps.Actions) != 0 || caps.Can("read") {
+		t.Errorf("Actions = %v, want none", caps.Actions)
+	}
+}
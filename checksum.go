@@ -0,0 +1,100 @@
+package espoclient
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrChecksumMismatch is the cause of errors reported when a response body does not
+// match the Content-MD5 header sent with it.
+var ErrChecksumMismatch = errors.New("payload checksum mismatch")
+
+// SetPayloadChecksums enables the Content-MD5 header on request bodies and the
+// verification of response bodies that carry one, catching corruption introduced
+// by intermediaries. Request bodies given as a plain io.Reader are buffered in
+// memory to compute the checksum; io.ReadSeeker bodies, files and Body values are
+// hashed in a separate pass instead.
+func (c *Client) SetPayloadChecksums(enabled bool) *Client {
+	c.payloadChecksums = enabled
+	return c
+}
+
+// checksumBody computes the base64 MD5 of a request body and returns a reader that
+// still yields the full body.
+func checksumBody(body io.Reader) (io.Reader, string, error) {
+	hash := md5.New()
+
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.Copy(hash, seeker); err != nil {
+			return nil, "", err
+		}
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, "", err
+		}
+		return body, base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, "", err
+	}
+	hash.Write(data)
+	return bytes.NewReader(data), base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+// checksumStream computes the base64 MD5 of a re-openable body by reading it once.
+func checksumStream(body *Body) (string, error) {
+	rc, err := body.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	hash := md5.New()
+	if _, err := io.Copy(hash, rc); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+// streamResponse copies a response body to w, verifying its checksum on the way
+// when payload checksums are enabled.
+func (c *Client) streamResponse(resp *http.Response, w io.Writer) (*Response, error) {
+	expected := resp.Header.Get("Content-Md5")
+	hash := md5.New()
+	body := io.Reader(resp.Body)
+	if c.payloadChecksums && expected != "" {
+		body = io.TeeReader(body, hash)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return nil, &EspoError{Message: "failed to stream response body", Cause: err}
+	}
+	if c.payloadChecksums && expected != "" && base64.StdEncoding.EncodeToString(hash.Sum(nil)) != expected {
+		return nil, &EspoError{Message: "streamed response body failed verification", Cause: ErrChecksumMismatch}
+	}
+	return &Response{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Headers:     resp.Header,
+	}, nil
+}
+
+// verifyChecksum checks body against the Content-MD5 header, if present.
+func verifyChecksum(header http.Header, body []byte) error {
+	expected := header.Get("Content-Md5")
+	if expected == "" {
+		return nil
+	}
+	sum := md5.Sum(body)
+	if base64.StdEncoding.EncodeToString(sum[:]) != expected {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
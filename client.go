@@ -2,17 +2,22 @@ package espoclient
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/oauth2"
 )
 
 // Constants for HTTP Methods
@@ -20,6 +25,7 @@ const (
 	MethodGet     = http.MethodGet
 	MethodPost    = http.MethodPost
 	MethodPut     = http.MethodPut
+	MethodPatch   = http.MethodPatch
 	MethodDelete  = http.MethodDelete
 	MethodOptions = http.MethodOptions
 )
@@ -33,14 +39,53 @@ type Header struct {
 }
 
 // Client manages communication with the EspoCRM API.
+//
+// A Client is safe for concurrent use by multiple goroutines. Configure it before
+// sending requests: of the Set* methods, only the authentication ones
+// (SetApiKey, SetSecretKey, SetUsernameAndPassword, SetTokenAuth, SetTokenSource,
+// SetBearerToken) may be called while requests are in flight, e.g. to rotate keys;
+// each request uses the credentials set when it started.
 type Client struct {
 	baseURL    *url.URL
 	httpClient *http.Client
 	apiPath    string
-	username   *string
-	password   *string
-	apiKey     *string
-	secretKey  *string
+
+	authMu      *sync.RWMutex // guards the authentication fields below
+	username    *string
+	password    *string
+	apiKey      *string
+	secretKey   *string
+	tokenAuth   *tokenAuth // nil unless SetTokenAuth is used
+	tokenSource oauth2.TokenSource
+
+	recordHooks    []RecordHook
+	normalizer     *Normalizer
+	normalizerHook int // index of the normalizer's hook in recordHooks
+	metadata       *metadataCache
+	retryPolicy    RetryPolicy
+	limiter        *rateLimiter // nil when the rate is unlimited
+	clock          Clock
+	rand           *lockedRand
+
+	payloadChecksums bool
+	maxBodySize      int64 // 0 disables the request body limit
+	entityPolicies   map[string]EntityPolicy
+	dial             *dialConfig
+	transport        *http.Transport // the client's own transport; settings on it are ignored after SetHTTPClient
+	customHTTPClient bool            // set by SetHTTPClient
+	// transportOption names the first option given to New that configures the
+	// client's own transport, to reject combining it with WithHTTPClient.
+	transportOption string
+
+	slowThreshold time.Duration
+	onSlowRequest SlowRequestFunc
+	exists        *existsCache
+	logger        *clientLogger // nil disables logging
+	telemetry     *telemetry    // nil disables instrumentation
+	hmacDebug     io.Writer
+	headers       http.Header    // sent with every request, see WithHeaders
+	cache         *responseCache // nil disables response caching
+	dump          DumpFunc       // nil disables request dumps
 }
 
 // Response holds the API response details.
@@ -49,6 +94,8 @@ type Response struct {
 	ContentType string
 	Headers     http.Header
 	Body        []byte // Raw response body
+	// Dump is the capture of the request and response, set when SetDumpFunc is used.
+	Dump *RequestDump
 }
 
 // EspoError is a general error from the client.
@@ -64,10 +111,40 @@ func (e *EspoError) Error() string {
 	return "espoclient: " + e.Message
 }
 
+// Unwrap returns the underlying cause, for use with errors.Is and errors.As.
+func (e *EspoError) Unwrap() error {
+	return e.Cause
+}
+
+var (
+	// ErrValidation matches errors for responses with status 400, e.g. a record
+	// that failed validation or a malformed request.
+	ErrValidation = errors.New("espoclient: bad request")
+	// ErrUnauthorized matches errors for responses with status 401: missing or
+	// wrong credentials.
+	ErrUnauthorized = errors.New("espoclient: unauthorized")
+	// ErrForbidden matches errors for responses with status 403: the API user
+	// lacks access to the entity, record or action.
+	ErrForbidden = errors.New("espoclient: forbidden")
+	// ErrNotFound matches errors for responses with status 404, e.g. a missing record.
+	ErrNotFound = errors.New("espoclient: not found")
+	// ErrConflict matches errors for responses with status 409, e.g. a duplicate
+	// detected on create or an update of a record modified in the meantime (see
+	// ErrStaleRecord), and 412 for a failed If-Unmodified-Since precondition.
+	ErrConflict = errors.New("espoclient: conflict")
+	// ErrTooManyRequests matches errors for responses with status 429, when the
+	// server throttles the client.
+	ErrTooManyRequests = errors.New("espoclient: too many requests")
+	// ErrServer matches errors for responses with a 5xx status.
+	ErrServer = errors.New("espoclient: server error")
+)
+
 // ResponseError is returned when the API responds with a non-2xx status code.
 type ResponseError struct {
-	Response     *Response
-	ErrorMessage string // Content of X-Status-Reason header if available
+	Response *Response
+	// ErrorMessage is the reason given by the server: the X-Status-Reason header,
+	// or the message of a JSON error body.
+	ErrorMessage string
 }
 
 func (e *ResponseError) Error() string {
@@ -77,6 +154,31 @@ func (e *ResponseError) Error() string {
 	return fmt.Sprintf("espoclient: API error (HTTP %d)", e.Response.StatusCode)
 }
 
+// Is makes responses match the sentinel error for their status code (ErrNotFound,
+// ErrForbidden, ...) with errors.Is.
+func (e *ResponseError) Is(target error) bool {
+	status := e.Response.StatusCode
+	switch target {
+	case ErrValidation:
+		return status == http.StatusBadRequest
+	case ErrUnauthorized:
+		return status == http.StatusUnauthorized
+	case ErrForbidden:
+		return status == http.StatusForbidden
+	case ErrNotFound:
+		return status == http.StatusNotFound
+	case ErrConflict:
+		return status == http.StatusConflict || status == http.StatusPreconditionFailed
+	case ErrStaleRecord:
+		return e.stale()
+	case ErrTooManyRequests:
+		return status == http.StatusTooManyRequests
+	case ErrServer:
+		return status >= 500
+	}
+	return false
+}
+
 // GetParsedBody attempts to unmarshal the JSON response body into the provided value.
 func (r *Response) GetParsedBody(v any) error {
 	if len(r.Body) == 0 {
@@ -102,6 +204,7 @@ func (r *Response) GetBodyString() string {
 // NewClient creates a new EspoCRM API client.
 // urlStr should be the base URL of your EspoCRM instance (e.g., "https://myespo.example.com").
 // port is optional; if nil, the default for the scheme (80/443) is used.
+// New configures the client with options instead.
 func NewClient(urlStr string, port *int) (*Client, error) {
 	if !strings.HasSuffix(urlStr, "/") {
 		urlStr += "/"
@@ -115,44 +218,94 @@ func NewClient(urlStr string, port *int) (*Client, error) {
 		baseURL.Host = baseURL.Hostname() + ":" + strconv.Itoa(*port)
 	}
 
-	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: time.Second * 30, // Default timeout
-		},
-		apiPath: defaultApiPath,
-	}, nil
+	c := &Client{
+		baseURL:  baseURL,
+		authMu:   new(sync.RWMutex),
+		apiPath:  defaultApiPath,
+		metadata: newMetadataCache(),
+		clock:    systemClock{},
+		rand:     newLockedRand(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		dial:     newDialConfig(),
+		exists:   newExistsCache(),
+	}
+	c.transport = c.newTransport()
+	c.httpClient = &http.Client{
+		Transport: c.transport,
+		Timeout:   time.Second * 30, // Default timeout
+	}
+	return c, nil
+}
+
+// BaseURL returns the base URL of the EspoCRM instance.
+func (c *Client) BaseURL() *url.URL {
+	u := *c.baseURL
+	return &u
 }
 
 // SetHTTPClient allows setting a custom http.Client (e.g., for custom transport, timeouts).
+// The client is copied, so later changes to it, such as its Timeout, do not apply,
+// and SetTimeout does not modify it. Its transport replaces the client's own one,
+// whose TLS, proxy, pool and dial settings are then ignored. A nil client restores
+// the client's own transport.
 func (c *Client) SetHTTPClient(client *http.Client) *Client {
-	c.httpClient = client
+	if client == nil {
+		c.httpClient = &http.Client{Transport: c.transport, Timeout: c.httpClient.Timeout}
+		c.customHTTPClient = false
+		return c
+	}
+	hc := *client
+	c.httpClient = &hc
+	c.customHTTPClient = true
+	return c
+}
+
+// SetTimeout sets the time limit for a single attempt of a request, including
+// reading the response body. A timeout of zero means no timeout. WithTimeout and
+// WithDeadline replace it for a single request.
+func (c *Client) SetTimeout(timeout time.Duration) *Client {
+	// Copy rather than modify: the http.Client may be shared with clients derived
+	// with WithAuth or WithHeaders.
+	hc := *c.httpClient
+	hc.Timeout = timeout
+	c.httpClient = &hc
 	return c
 }
 
 // SetUsernameAndPassword sets credentials for Basic Authentication. Not recommended.
 func (c *Client) SetUsernameAndPassword(username, password string) *Client {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
 	c.username = &username
 	c.password = &password
-	c.apiKey = nil    // Clear other auth methods
-	c.secretKey = nil // Clear other auth methods
+	c.apiKey = nil      // Clear other auth methods
+	c.secretKey = nil   // Clear other auth methods
+	c.tokenAuth = nil   // Clear other auth methods
+	c.tokenSource = nil // Clear other auth methods
 	return c
 }
 
 // SetApiKey sets the API Key for authentication.
 func (c *Client) SetApiKey(apiKey string) *Client {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
 	c.apiKey = &apiKey
 	c.username = nil // Clear other auth methods
 	c.password = nil // Clear other auth methods
 	// Keep secretKey if it was set for potential HMAC auth
+	c.tokenAuth = nil   // Clear other auth methods
+	c.tokenSource = nil // Clear other auth methods
 	return c
 }
 
 // SetSecretKey sets the Secret Key for HMAC authentication (requires API Key to also be set).
 func (c *Client) SetSecretKey(secretKey string) *Client {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
 	c.secretKey = &secretKey
-	c.username = nil // Clear other auth methods
-	c.password = nil // Clear other auth methods
+	c.username = nil    // Clear other auth methods
+	c.password = nil    // Clear other auth methods
+	c.tokenAuth = nil   // Clear other auth methods
+	c.tokenSource = nil // Clear other auth methods
 	return c
 }
 
@@ -161,15 +314,56 @@ func (c *Client) SetSecretKey(secretKey string) *Client {
 // path: The API endpoint path (e.g., "Lead", "Account/some-id").
 // data: The request payload.
 //   - For GET: map[string]string or url.Values for query parameters.
-//   - For POST/PUT/DELETE:
+//   - For POST/PUT/PATCH/DELETE:
 //   - Any struct or map[string]any will be JSON-encoded.
 //   - url.Values will be form-urlencoded.
 //   - io.Reader will be streamed directly (Content-Type header should be set manually).
+//     Readers that report their Len get a Content-Length.
+//   - *Body and regular *os.File values are streamed without buffering, with a
+//     Content-Length when known, and can be re-sent on retry (see FileBody).
 //   - []byte will be sent directly (Content-Type header should be set manually).
 //   - string will be sent directly (Content-Type header should be set manually).
 //
 // headers: A map of additional headers to send.
-func (c *Client) Request(method, path string, data any, headers map[string]string) (*Response, error) {
+// opts: Optional per-request settings (see RequestOption).
+func (c *Client) Request(method, path string, data any, headers map[string]string, opts ...RequestOption) (*Response, error) {
+	return c.request(context.Background(), method, path, data, headers, opts...)
+}
+
+// RequestWithContext is like Request, but the request is bound to ctx: it is
+// aborted, including any retry waits, when ctx is cancelled or its deadline passes.
+func (c *Client) RequestWithContext(ctx context.Context, method, path string, data any, headers map[string]string, opts ...RequestOption) (*Response, error) {
+	return c.request(ctx, method, path, data, headers, opts...)
+}
+
+// request is the context-aware implementation behind Request, used by the higher-level helpers.
+func (c *Client) request(ctx context.Context, method, path string, data any, headers map[string]string, opts ...RequestOption) (*Response, error) {
+	options := newRequestOptions(slices.Concat(requestOptionsFromContext(ctx), opts))
+	auth := c.auth()
+	if t := auth.token; t != nil && options.credentials == nil {
+		return c.requestWithToken(ctx, t, replayable(data), func(auth RequestOption) (*Response, error) {
+			return c.request(ctx, method, path, data, headers, slices.Concat(opts, []RequestOption{auth})...)
+		})
+	}
+	if policy, entity, ok := c.entityPolicy(path, data); ok {
+		if err := checkEntityPolicy(policy, entity, method, path); err != nil {
+			return nil, err
+		}
+		if options.timeout == 0 {
+			options.timeout = policy.Timeout
+		}
+	}
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer options.releaseContext(cancel)
+	}
+	if !options.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, options.deadline)
+		defer options.releaseContext(cancel)
+	}
+
 	// 1. Compose URL
 	rel, err := url.Parse(strings.TrimPrefix(c.apiPath, "/") + strings.TrimPrefix(path, "/"))
 	if err != nil {
@@ -178,7 +372,18 @@ func (c *Client) Request(method, path string, data any, headers map[string]strin
 	fullURL := c.baseURL.ResolveReference(rel)
 
 	// 2. Prepare Request Body and Query Params
+	data, err = c.applyRecordHooks(ctx, method, path, data)
+	if err != nil {
+		return nil, err
+	}
+	if options.versionNumber != nil || options.ifUnmodifiedSince != "" {
+		if data, err = c.applyPrecondition(ctx, method, path, data, options); err != nil {
+			return nil, err
+		}
+	}
+
 	var reqBody io.Reader
+	var stream *Body
 	contentType := "" // Detected or default content type
 
 	if method == MethodGet && data != nil {
@@ -200,9 +405,26 @@ func (c *Client) Request(method, path string, data any, headers map[string]strin
 		fullURL.RawQuery = query.Encode()
 	} else if data != nil {
 		// Handle non-GET request body
+		if f, ok := data.(*os.File); ok {
+			// Pipes and other non-regular files are streamed once, as plain readers.
+			if body, err := FileBody(f); err == nil {
+				data = body
+			}
+		}
 		switch v := data.(type) {
+		case *Body:
+			stream = v
+			rc, err := v.Open()
+			if err != nil {
+				return nil, &EspoError{Message: "failed to open request body", Cause: err}
+			}
+			reqBody = rc
+			contentType = v.ContentType
 		case io.Reader:
 			reqBody = v // Stream directly
+			if n := readerLen(v); n >= 0 {
+				stream = &Body{Size: n}
+			}
 		case []byte:
 			reqBody = bytes.NewReader(v)
 		case string:
@@ -221,29 +443,85 @@ func (c *Client) Request(method, path string, data any, headers map[string]strin
 		}
 	}
 
+	if len(options.query) > 0 {
+		query := fullURL.Query()
+		for key, vals := range options.query {
+			for _, val := range vals {
+				query.Add(key, val)
+			}
+		}
+		fullURL.RawQuery = query.Encode()
+	}
+	if method == MethodGet && (len(options.selectAttrs) > 0 || len(options.links) > 0) {
+		query := fullURL.Query()
+		selectValue, err := c.selectValue(ctx, path, query.Get("select"), options)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("select", selectValue)
+		fullURL.RawQuery = query.Encode()
+	}
+
+	var checksum string
+	if c.payloadChecksums && reqBody != nil {
+		if stream != nil && stream.Open != nil {
+			checksum, err = checksumStream(stream)
+		} else {
+			reqBody, checksum, err = checksumBody(reqBody)
+			stream = nil // buffered or rewound by checksumBody
+		}
+		if err != nil {
+			return nil, &EspoError{Message: "failed to compute payload checksum", Cause: err}
+		}
+	}
+
 	// 3. Create Request
-	req, err := http.NewRequest(method, fullURL.String(), reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL.String(), reqBody)
 	if err != nil {
 		return nil, &EspoError{Message: "failed to create HTTP request", Cause: err}
 	}
+	if stream != nil && req.GetBody == nil {
+		req.ContentLength = stream.Size
+		if stream.Size == 0 {
+			req.Body.Close()
+			req.Body = http.NoBody
+		}
+		if stream.Open != nil {
+			req.GetBody = stream.Open
+		}
+	}
+	if c.maxBodySize > 0 {
+		if err := c.limitBody(req); err != nil {
+			return nil, err
+		}
+	}
 
 	// 4. Set Headers (including authentication and content type)
 
 	// Authentication Headers (HMAC takes precedence)
-	if c.apiKey != nil && c.secretKey != nil {
-		// HMAC Auth
-		hmacString := method + " /" + strings.TrimPrefix(path, "/")
-		mac := hmac.New(sha256.New, []byte(*c.secretKey))
-		mac.Write([]byte(hmacString))
-		signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
-		authPart := base64.StdEncoding.EncodeToString([]byte(*c.apiKey + ":" + signature))
-		req.Header.Set("X-Hmac-Authorization", authPart)
-	} else if c.apiKey != nil {
-		// API Key Auth
-		req.Header.Set("X-Api-Key", *c.apiKey)
-	} else if c.username != nil && c.password != nil {
-		// Basic Auth
-		req.SetBasicAuth(*c.username, *c.password)
+	cred := auth.credentials
+	if options.credentials != nil {
+		cred = *options.credentials
+	} else if auth.source != nil {
+		if err := applyBearerToken(req, auth.source); err != nil {
+			return nil, err
+		}
+	}
+	options.stringToSign = cred.apply(req, c.apiPath)
+	if options.stringToSign != "" && c.hmacDebug != nil {
+		fmt.Fprintf(c.hmacDebug, "espoclient: HMAC string to sign: %q\n", options.stringToSign)
+	}
+
+	if checksum != "" {
+		req.Header.Set("Content-MD5", checksum)
+	}
+
+	if options.idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", options.idempotencyKey)
+	}
+
+	for k, vals := range c.headers {
+		req.Header[k] = vals
 	}
 
 	// Content-Type Header (if detected/defaulted and not overridden by user)
@@ -263,20 +541,64 @@ func (c *Client) Request(method, path string, data any, headers map[string]strin
 		req.Header.Set("Content-Type", contentType)
 	}
 
-	// 5. Execute Request
-	resp, err := c.httpClient.Do(req)
+	for k, vals := range options.headers {
+		req.Header[http.CanonicalHeaderKey(k)] = vals
+	}
+
+	// 5. Execute Request, retrying transient failures (see retry.go)
+	start := c.clock.Now()
+	defer c.reportSlowRequest(method, path, start)
+	send := func(req *http.Request) (*Response, error) {
+		req, finish := c.instrument(req, path)
+		resp, err := c.executeWithRetry(req, options)
+		finish(resp, err)
+		return resp, err
+	}
+	if c.cache != nil {
+		return c.cachedSend(req, requestEntity(path, data), options, send)
+	}
+	return send(req)
+}
+
+// execute sends a prepared request once and converts the result into a Response.
+func (c *Client) execute(req *http.Request, options *requestOptions) (*Response, error) {
+	httpClient := c.httpClient
+	if options.overridesTimeout() {
+		// The request's context carries its time limit.
+		noTimeout := *httpClient
+		noTimeout.Timeout = 0
+		httpClient = &noTimeout
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, &EspoError{Message: "HTTP request execution failed", Cause: err}
 	}
+	if options.stream != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		options.stream.Body = resp.Body // closed by the caller
+		return &Response{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Headers:     resp.Header,
+		}, nil
+	}
 	defer resp.Body.Close() // Ensure body is always closed
 
-	// 6. Read Response Body
+	if options.output != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return c.streamResponse(resp, options.output)
+	}
+
+	// Read Response Body
 	respBodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, &EspoError{Message: "failed to read response body", Cause: err}
 	}
+	if c.payloadChecksums {
+		if err := verifyChecksum(resp.Header, respBodyBytes); err != nil {
+			return nil, &EspoError{Message: "response body failed verification", Cause: err}
+		}
+	}
 
-	// 7. Create Response Object
+	// Create Response Object
 	apiResponse := &Response{
 		StatusCode:  resp.StatusCode,
 		ContentType: resp.Header.Get("Content-Type"),
@@ -284,16 +606,12 @@ func (c *Client) Request(method, path string, data any, headers map[string]strin
 		Body:        respBodyBytes,
 	}
 
-	// 8. Check for API Errors (non-2xx status)
+	// Check for API Errors (non-2xx status)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		// Use ResponseError to wrap the Response object
-		responseErr := &ResponseError{
-			Response:     apiResponse,
-			ErrorMessage: resp.Header.Get("X-Status-Reason"), // Get potential error message
-		}
-		return nil, responseErr
+		return nil, newResponseError(apiResponse)
 	}
 
-	// 9. Return Success Response
+	// Return Success Response
 	return apiResponse, nil
 }
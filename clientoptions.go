@@ -0,0 +1,234 @@
+package espoclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Option configures a Client created by New.
+type Option func(*Client) error
+
+// New creates an EspoCRM API client for the instance at urlStr, configured by opts.
+// Options are applied in order. WithHTTPClient cannot be combined with the options
+// that configure the client's own transport (WithTLSConfig, WithConnectionPool,
+// WithProxyURL and WithHostResolution); configure the transport of the given
+// http.Client instead.
+//
+//	client, err := espoclient.New("https://crm.example.com",
+//		espoclient.WithAPIKey(apiKey),
+//		espoclient.WithClientTimeout(10*time.Second),
+//	)
+func New(urlStr string, opts ...Option) (*Client, error) {
+	c, err := NewClient(urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.customHTTPClient && c.transportOption != "" {
+		return nil, &EspoError{Message: "WithHTTPClient cannot be combined with " + c.transportOption + ", which configures the replaced transport"}
+	}
+	c.transportOption = ""
+	return c, nil
+}
+
+// WithPort overrides the port of the base URL.
+func WithPort(port int) Option {
+	return func(c *Client) error {
+		if port < 1 || port > 65535 {
+			return &EspoError{Message: fmt.Sprintf("invalid port %d", port)}
+		}
+		c.baseURL.Host = c.baseURL.Hostname() + ":" + strconv.Itoa(port)
+		return nil
+	}
+}
+
+// WithAPIPath sets the path of the API relative to the base URL ("/api/v1/" by default).
+func WithAPIPath(path string) Option {
+	return func(c *Client) error {
+		if _, err := url.Parse(path); err != nil {
+			return &EspoError{Message: "invalid API path", Cause: err}
+		}
+		c.SetAPIPath(path)
+		return nil
+	}
+}
+
+// WithAPIVersion targets another version of the API.
+func WithAPIVersion(version APIVersion) Option {
+	return func(c *Client) error {
+		c.SetAPIVersion(version)
+		return nil
+	}
+}
+
+// WithPortal sends requests through the portal access API of a portal, see SetPortal.
+func WithPortal(portalID string) Option {
+	return func(c *Client) error {
+		c.SetPortal(portalID)
+		return nil
+	}
+}
+
+// WithClientTimeout sets the time limit for a single attempt of every request, see
+// SetTimeout. Use WithTimeout to limit a single call instead.
+func WithClientTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.SetTimeout(timeout)
+		return nil
+	}
+}
+
+// WithHTTPClient sends requests with a copy of client instead of the client's own
+// one, see SetHTTPClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) error {
+		if client == nil {
+			return &EspoError{Message: "nil HTTP client"}
+		}
+		c.SetHTTPClient(client)
+		return nil
+	}
+}
+
+// markTransportOption records that an option configured the client's own transport.
+func (c *Client) markTransportOption(name string) {
+	if c.transportOption == "" {
+		c.transportOption = name
+	}
+}
+
+// WithAPIKey authenticates with an API key.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) error {
+		c.SetApiKey(apiKey)
+		return nil
+	}
+}
+
+// WithHMAC authenticates with an API key and secret key (HMAC authorization).
+func WithHMAC(apiKey, secretKey string) Option {
+	return func(c *Client) error {
+		c.SetApiKey(apiKey).SetSecretKey(secretKey)
+		return nil
+	}
+}
+
+// WithBasicAuth authenticates with a username and password.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) error {
+		c.SetUsernameAndPassword(username, password)
+		return nil
+	}
+}
+
+// WithTokenAuth authenticates with an auth token obtained for username and
+// password, see SetTokenAuth.
+func WithTokenAuth(username, password string) Option {
+	return func(c *Client) error {
+		c.SetTokenAuth(username, password)
+		return nil
+	}
+}
+
+// WithTokenSource authenticates with OAuth 2.0 / OIDC access tokens from src, see
+// SetTokenSource.
+func WithTokenSource(src oauth2.TokenSource) Option {
+	return func(c *Client) error {
+		c.SetTokenSource(src)
+		return nil
+	}
+}
+
+// WithRetryPolicy retries transient failures according to policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) error {
+		c.SetRetryPolicy(policy)
+		return nil
+	}
+}
+
+// WithRateLimit limits the rate of requests sent by the client.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *Client) error {
+		c.SetRateLimit(limit)
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the client's transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) error {
+		c.SetTLSConfig(config)
+		c.markTransportOption("WithTLSConfig")
+		return nil
+	}
+}
+
+// WithConnectionPool tunes connection reuse, see SetConnectionPool.
+func WithConnectionPool(pool ConnectionPool) Option {
+	return func(c *Client) error {
+		c.SetConnectionPool(pool)
+		c.markTransportOption("WithConnectionPool")
+		return nil
+	}
+}
+
+// WithProxyURL sends requests through an HTTP(S) or SOCKS5 proxy.
+func WithProxyURL(proxyURL *url.URL) Option {
+	return func(c *Client) error {
+		c.SetProxyURL(proxyURL)
+		c.markTransportOption("WithProxyURL")
+		return nil
+	}
+}
+
+// WithHostResolution connects to ip whenever host is dialed, see SetHostResolution.
+func WithHostResolution(host, ip string) Option {
+	return func(c *Client) error {
+		c.SetHostResolution(host, ip)
+		c.markTransportOption("WithHostResolution")
+		return nil
+	}
+}
+
+// WithCache caches GET responses in store, see SetCache.
+func WithCache(store CacheStore, ttl time.Duration) Option {
+	return func(c *Client) error {
+		c.SetCache(store, ttl)
+		return nil
+	}
+}
+
+// WithLogger logs requests to logger, see SetLogger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) error {
+		c.SetLogger(logger)
+		return nil
+	}
+}
+
+// WithDumpFunc passes a capture of every request attempt to fn, see SetDumpFunc.
+func WithDumpFunc(fn DumpFunc) Option {
+	return func(c *Client) error {
+		c.SetDumpFunc(fn)
+		return nil
+	}
+}
+
+// WithTelemetry instruments API calls with OpenTelemetry, see SetTelemetry.
+func WithTelemetry(opts ...TelemetryOption) Option {
+	return func(c *Client) error {
+		return c.SetTelemetry(opts...)
+	}
+}
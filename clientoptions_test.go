@@ -0,0 +1,38 @@
+package espoclient_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestWithHTTPClientIsCopied(t *testing.T) {
+	hc := &http.Client{Timeout: time.Minute}
+	_, err := espoclient.New("https://crm.example.com",
+		espoclient.WithHTTPClient(hc),
+		espoclient.WithClientTimeout(5*time.Second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hc.Timeout != time.Minute {
+		t.Errorf("the given http.Client was modified: Timeout = %v", hc.Timeout)
+	}
+}
+
+func TestWithHTTPClientAndTransportOptions(t *testing.T) {
+	for _, order := range [][]espoclient.Option{
+		{espoclient.WithTLSConfig(&tls.Config{}), espoclient.WithHTTPClient(&http.Client{})},
+		{espoclient.WithHTTPClient(&http.Client{}), espoclient.WithTLSConfig(&tls.Config{})},
+	} {
+		if _, err := espoclient.New("https://crm.example.com", order...); err == nil {
+			t.Error("New accepted WithHTTPClient with WithTLSConfig")
+		}
+	}
+	if _, err := espoclient.New("https://crm.example.com", espoclient.WithTLSConfig(&tls.Config{})); err != nil {
+		t.Errorf("WithTLSConfig alone: %v", err)
+	}
+}
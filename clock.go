@@ -0,0 +1,50 @@
+package espoclient
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for retries, caches and other time-based behavior.
+// Tests can install a fake implementation with SetClock to avoid sleeping real time.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SetClock replaces the clock used by the client.
+func (c *Client) SetClock(clock Clock) *Client {
+	c.clock = clock
+	return c
+}
+
+// SetRandSource replaces the source of randomness used for jitter, so tests can
+// make it deterministic (e.g. rand.NewPCG(1, 2)).
+func (c *Client) SetRandSource(src rand.Source) *Client {
+	c.rand = newLockedRand(src)
+	return c
+}
+
+// lockedRand is a *rand.Rand that is safe for concurrent use.
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func newLockedRand(src rand.Source) *lockedRand {
+	return &lockedRand{r: rand.New(src)}
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (l *lockedRand) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float64()
+}
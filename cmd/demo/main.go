@@ -13,17 +13,14 @@ func main() {
 	apiKey := "your-api-key"                        // CHANGE THIS
 	// secretKey := "your-secret-key"                  // CHANGE THIS (if using HMAC)
 
-	// Create a new client
-	client, err := espoclient.NewClient(yourEspoURL, nil) // Use nil for default port
+	// Create a new client with authentication - choose one method:
+	//   espoclient.WithBasicAuth("admin", "password") // Basic Auth (not recommended)
+	//   espoclient.WithHMAC(apiKey, secretKey)         // HMAC
+	client, err := espoclient.New(yourEspoURL, espoclient.WithAPIKey(apiKey))
 	if err != nil {
 		log.Fatalf("Error creating client: %v", err)
 	}
 
-	// Set authentication - Choose one method:
-	// client.SetUsernameAndPassword("admin", "password") // Basic Auth (not recommended)
-	client.SetApiKey(apiKey)
-	// client.SetSecretKey(secretKey) // Uncomment if using HMAC
-
 	// --- Example: Create a Lead (POST request) ---
 	fmt.Println("Attempting to create a Lead...")
 	leadData := map[string]any{
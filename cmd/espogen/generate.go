@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// generator renders Go source from EspoCRM metadata.
+type generator struct {
+	pkg      string
+	metadata map[string]any
+	entities []string // explicit entity list; all entities if empty
+	services bool     // emit per-entity service types
+
+	importClient  bool // the generated code refers to the espoclient package
+	importContext bool // the generated code refers to the context package
+
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// generate returns the formatted source file.
+func (g *generator) generate() ([]byte, error) {
+	entities := g.entityNames()
+	g.genEntityConstants(entities)
+	for _, entity := range entities {
+		g.genEnums(entity)
+		g.genStruct(entity)
+		if g.services {
+			g.genServices(entity)
+		}
+	}
+
+	// The imports depend on the generated code, so the header is written last.
+	body := g.buf.Bytes()
+	g.buf = bytes.Buffer{}
+	g.printf("// Code generated by espogen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", g.pkg)
+	switch {
+	case g.importContext:
+		g.printf("import (\n\t\"context\"\n\n\tespoclient \"github.com/egorsmkv/go-espo-api-client\"\n)\n\n")
+	case g.importClient:
+		g.printf("import espoclient \"github.com/egorsmkv/go-espo-api-client\"\n\n")
+	}
+	g.buf.Write(body)
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+	return src, nil
+}
+
+// genEntityConstants emits one constant per entity type name.
+func (g *generator) genEntityConstants(entities []string) {
+	g.printf("// Entity type names.\nconst (\n")
+	for _, entity := range entities {
+		g.printf("\tEntity%s = %q\n", goName(entity), entity)
+	}
+	g.printf(")\n\n")
+}
+
+// genEnums emits a string type and its constants for every enum and multiEnum field.
+func (g *generator) genEnums(entity string) {
+	fields := g.fields(entity)
+	for _, field := range sortedKeys(fields) {
+		def, _ := fields[field].(map[string]any)
+		fieldType, _ := def["type"].(string)
+		if fieldType != "enum" && fieldType != "multiEnum" {
+			continue
+		}
+		options, _ := def["options"].([]any)
+		if len(options) == 0 {
+			continue
+		}
+
+		typeName := enumTypeName(entity, field)
+		g.printf("// %s is an option of %s.%s.\n", typeName, entity, field)
+		g.printf("type %s string\n\n", typeName)
+		g.printf("const (\n")
+		seen := map[string]bool{}
+		for _, option := range options {
+			value, ok := option.(string)
+			if !ok {
+				continue
+			}
+			name := typeName + goName(value)
+			if value == "" {
+				name = typeName + "Empty"
+			}
+			for base, i := name, 2; seen[name]; i++ {
+				name = base + strconv.Itoa(i)
+			}
+			seen[name] = true
+			g.printf("\t%s %s = %q\n", name, typeName, value)
+		}
+		g.printf(")\n\n")
+	}
+}
+
+// entityNames returns the entity types to generate, sorted.
+func (g *generator) entityNames() []string {
+	if len(g.entities) > 0 {
+		names := make([]string, 0, len(g.entities))
+		for _, name := range g.entities {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	scopes, _ := g.metadata["scopes"].(map[string]any)
+	var names []string
+	for name, scope := range scopes {
+		if def, ok := scope.(map[string]any); ok && def["entity"] == true {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fields returns the field definitions of an entity.
+func (g *generator) fields(entity string) map[string]any {
+	defs, _ := g.metadata["entityDefs"].(map[string]any)
+	def, _ := defs[entity].(map[string]any)
+	fields, _ := def["fields"].(map[string]any)
+	return fields
+}
+
+func enumTypeName(entity, field string) string {
+	return goName(entity) + goName(field)
+}
+
+// goName converts an EspoCRM name or option value into an exported Go identifier.
+func goName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
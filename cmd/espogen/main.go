@@ -0,0 +1,92 @@
+// Command espogen connects to an EspoCRM instance, reads its metadata and
+// generates Go source for the entities it defines: entity type constants, a struct
+// per entity with JSON tags, typed enum options and link name constants, and
+// optionally (-services) typed service clients.
+//
+// Usage:
+//
+//	espogen -url https://crm.example.com -api-key KEY -package crm -out crm/espo_gen.go
+//
+// The connection can instead be configured with -config, a YAML or TOML file read
+// by espoclient.LoadConfig. Without -url or -config, it is configured from the
+// ESPO_* environment variables (see espoclient.NewClientFromEnv).
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func main() {
+	var (
+		config    = flag.String("config", "", "YAML or TOML client configuration file")
+		baseURL   = flag.String("url", "", "base URL of the EspoCRM instance (default from ESPO_* environment variables)")
+		apiKey    = flag.String("api-key", "", "API key")
+		secretKey = flag.String("secret-key", "", "secret key for HMAC authentication")
+		username  = flag.String("username", "", "username for Basic authentication")
+		password  = flag.String("password", "", "password for Basic authentication")
+		pkg       = flag.String("package", "espo", "package name of the generated file")
+		out       = flag.String("out", "", "output file (default stdout)")
+		entities  = flag.String("entities", "", "comma-separated entity types to generate (default all)")
+		services  = flag.Bool("services", false, "generate per-entity service types")
+	)
+	flag.Parse()
+
+	var client *espoclient.Client
+	var err error
+	switch {
+	case *config != "":
+		var cfg *espoclient.Config
+		if cfg, err = espoclient.LoadConfig(*config); err == nil {
+			client, err = espoclient.NewClientFromConfig(cfg)
+		}
+	case *baseURL != "":
+		client, err = espoclient.NewClient(*baseURL, nil)
+	default:
+		client, err = espoclient.NewClientFromEnv()
+	}
+	if err != nil {
+		log.Fatalf("espogen: %v", err)
+	}
+	switch {
+	case *apiKey != "":
+		client.SetApiKey(*apiKey)
+		if *secretKey != "" {
+			client.SetSecretKey(*secretKey)
+		}
+	case *username != "":
+		client.SetUsernameAndPassword(*username, *password)
+	}
+
+	metadata, err := client.GetMetadata(context.Background())
+	if err != nil {
+		log.Fatalf("espogen: fetching metadata: %v", err)
+	}
+
+	g := &generator{
+		pkg:      *pkg,
+		metadata: metadata,
+		services: *services,
+	}
+	if *entities != "" {
+		g.entities = strings.Split(*entities, ",")
+	}
+
+	src, err := g.generate()
+	if err != nil {
+		log.Fatalf("espogen: %v", err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("espogen: %v", err)
+	}
+}
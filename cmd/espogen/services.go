@@ -0,0 +1,54 @@
+package main
+
+import "sort"
+
+// genServices emits a service type per entity with typed CRUD inherited from
+// espoclient.EntityService and link helpers for the entity's to-many relations.
+// The services are generic over the record type, so they work with generated
+// structs, hand-written ones or maps.
+func (g *generator) genServices(entity string) {
+	name := goName(entity)
+	service := name + "Service"
+	g.importClient = true
+
+	g.printf("// %s provides typed access to %s records.\n", service, entity)
+	g.printf("type %s[T any] struct {\n\t*espoclient.EntityService[T]\n}\n\n", service)
+	g.printf("// New%s returns a %s decoding records into T.\n", service, service)
+	g.printf("func New%s[T any](c *espoclient.Client) *%s[T] {\n", service, service)
+	g.printf("\treturn &%s[T]{espoclient.NewEntityService[T](c, Entity%s)}\n}\n\n", service, name)
+
+	for _, link := range g.toManyLinks(entity) {
+		g.importContext = true
+		linkName := goName(link)
+		g.printf("// Link%s relates records to a %s through the %q link.\n", linkName, entity, link)
+		g.printf("func (s *%s[T]) Link%s(ctx context.Context, id string, foreignIDs ...string) error {\n", service, linkName)
+		g.printf("\treturn s.Link(ctx, id, %q, foreignIDs...)\n}\n\n", link)
+
+		g.printf("// Unlink%s removes records from the %q link of a %s.\n", linkName, link, entity)
+		g.printf("func (s *%s[T]) Unlink%s(ctx context.Context, id string, foreignIDs ...string) error {\n", service, linkName)
+		g.printf("\treturn s.Unlink(ctx, id, %q, foreignIDs...)\n}\n\n", link)
+
+		g.printf("// %s iterates over the records in the %q link of a %s.\n", linkName, link, entity)
+		g.printf("func (s *%s[T]) %s(ctx context.Context, id string, params *espoclient.SearchParams) *espoclient.Iterator {\n", service, linkName)
+		g.printf("\treturn s.Related(ctx, id, %q, params)\n}\n\n", link)
+	}
+}
+
+// toManyLinks returns the names of the entity's hasMany links, which support
+// relating and unrelating records through {Entity}/{id}/{link}.
+func (g *generator) toManyLinks(entity string) []string {
+	defs, _ := g.metadata["entityDefs"].(map[string]any)
+	def, _ := defs[entity].(map[string]any)
+	links, _ := def["links"].(map[string]any)
+
+	var names []string
+	for name, link := range links {
+		l, ok := link.(map[string]any)
+		if !ok || l["type"] != "hasMany" || l["disabled"] == true {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+	"testing"
+)
+
+func TestGenerateServicesImports(t *testing.T) {
+	tests := []struct {
+		name  string
+		links map[string]any
+		want  []string
+	}{
+		{"no hasMany links", map[string]any{
+			"account": map[string]any{"type": "belongsTo"},
+		}, []string{"github.com/egorsmkv/go-espo-api-client"}},
+		{"hasMany link", map[string]any{
+			"contacts": map[string]any{"type": "hasMany"},
+		}, []string{"context", "github.com/egorsmkv/go-espo-api-client"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &generator{
+				pkg:      "espo",
+				services: true,
+				metadata: map[string]any{
+					"scopes": map[string]any{"Opportunity": map[string]any{"entity": true}},
+					"entityDefs": map[string]any{"Opportunity": map[string]any{
+						"fields": map[string]any{"name": map[string]any{"type": "varchar"}},
+						"links":  tt.links,
+					}},
+				},
+			}
+			src, err := g.generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			file, err := parser.ParseFile(token.NewFileSet(), "gen.go", src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			used := map[string]bool{}
+			ast.Inspect(file, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if x, ok := sel.X.(*ast.Ident); ok {
+						used[x.Name] = true
+					}
+				}
+				return true
+			})
+			var imports []string
+			for _, spec := range file.Imports {
+				importPath, _ := strconv.Unquote(spec.Path.Value)
+				imports = append(imports, importPath)
+				name := path.Base(importPath)
+				if spec.Name != nil {
+					name = spec.Name.Name
+				}
+				if !used[name] {
+					t.Errorf("%q imported and not used:\n%s", importPath, src)
+				}
+			}
+			if len(imports) != len(tt.want) {
+				t.Errorf("imports = %q, want %q", imports, tt.want)
+			}
+		})
+	}
+}
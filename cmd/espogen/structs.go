@@ -0,0 +1,229 @@
+package main
+
+import "strings"
+
+// attribute is a struct field of a generated entity type.
+type attribute struct {
+	name     string // JSON attribute name
+	goType   string
+	typeName string // Go field name
+}
+
+// genStruct emits a struct with one field per attribute of the entity and a
+// constant per link. Attributes are omitted from JSON when they hold their zero
+// value, so the struct can be used for partial updates; use espoclient.Payload to
+// clear a value. Dates are pointers to espoclient.Date and espoclient.DateTime,
+// which round-trip EspoCRM's formats and are omitted when nil. Link fields get
+// accessors converting their flat attributes from and to espoclient.Link,
+// LinkMultiple and LinkParent, e.g. AccountLink and SetAccountLink.
+func (g *generator) genStruct(entity string) {
+	name := goName(entity)
+	attrs := []attribute{{name: "id", goType: "string", typeName: "ID"}}
+	seen := map[string]bool{"ID": true}
+
+	var accessors []linkAccessor
+	fields := g.fields(entity)
+	for _, field := range sortedKeys(fields) {
+		def, _ := fields[field].(map[string]any)
+		if def["disabled"] == true || def["utility"] == true {
+			continue
+		}
+		fieldAttrs := fieldAttributes(entity, field, def)
+		complete := true
+		for _, attr := range fieldAttrs {
+			if seen[attr.typeName] {
+				complete = false
+				continue
+			}
+			seen[attr.typeName] = true
+			attrs = append(attrs, attr)
+			if strings.Contains(attr.goType, "espoclient.") {
+				g.importClient = true
+			}
+		}
+		fieldType, _ := def["type"].(string)
+		if a, ok := newLinkAccessor(field, fieldType, fieldAttrs); ok && complete {
+			accessors = append(accessors, a)
+		}
+	}
+
+	g.printf("// %s holds the attributes of %s records.\n", name, entity)
+	g.printf("type %s struct {\n", name)
+	for _, attr := range attrs {
+		tag := attr.name
+		if attr.name != "id" {
+			tag += ",omitempty"
+		}
+		g.printf("\t%s %s `json:%q`\n", attr.typeName, attr.goType, tag)
+	}
+	g.printf("}\n\n")
+
+	for _, a := range accessors {
+		if seen[a.method] || seen["Set"+a.method] {
+			continue // would clash with a field
+		}
+		g.importClient = true
+		a.gen(g, name)
+	}
+
+	links := g.links(entity)
+	if len(links) == 0 {
+		return
+	}
+	g.printf("// Links of %s.\nconst (\n", entity)
+	for _, link := range links {
+		g.printf("\t%sLink%s = %q\n", name, goName(link), link)
+	}
+	g.printf(")\n\n")
+}
+
+// linkAccessor describes the methods that convert the flat attributes of a link,
+// link-multiple or link-parent field from and to espoclient.Link, LinkMultiple or
+// LinkParent.
+type linkAccessor struct {
+	field  string
+	method string // e.g. "AccountLink"; the setter is "Set" + method
+	goType string // e.g. "espoclient.Link"
+	fields []string
+	attrs  []string // Go fields holding the attributes, in the order of fields
+}
+
+// newLinkAccessor returns the accessor of a field, if it is a link field.
+func newLinkAccessor(field, fieldType string, attrs []attribute) (linkAccessor, bool) {
+	a := linkAccessor{field: field, method: goName(field) + "Link"}
+	switch fieldType {
+	case "link":
+		a.goType, a.fields = "espoclient.Link", []string{"ID", "Name"}
+	case "linkMultiple":
+		a.goType, a.fields = "espoclient.LinkMultiple", []string{"IDs", "Names"}
+	case "linkParent":
+		a.goType, a.fields = "espoclient.LinkParent", []string{"ID", "Type", "Name"}
+	default:
+		return a, false
+	}
+	for _, attr := range attrs {
+		a.attrs = append(a.attrs, attr.typeName)
+	}
+	return a, true
+}
+
+// gen emits the getter and setter of the accessor on the struct typeName.
+func (a linkAccessor) gen(g *generator, typeName string) {
+	g.printf("// %s returns the %s field.\n", a.method, a.field)
+	g.printf("func (r *%s) %s() %s {\n\treturn %s{", typeName, a.method, a.goType, a.goType)
+	for i, f := range a.fields {
+		if i > 0 {
+			g.printf(", ")
+		}
+		g.printf("%s: r.%s", f, a.attrs[i])
+	}
+	g.printf("}\n}\n\n")
+
+	g.printf("// Set%s sets the %s field. As the attributes are omitted when empty, use\n", a.method, a.field)
+	g.printf("// %s.Put with an espoclient.Payload to clear it in an update.\n", a.goType)
+	g.printf("func (r *%s) Set%s(l %s) {\n", typeName, a.method, a.goType)
+	for i, f := range a.fields {
+		g.printf("\tr.%s = l.%s\n", a.attrs[i], f)
+	}
+	g.printf("}\n\n")
+}
+
+// fieldAttributes returns the attributes EspoCRM stores for a field of the given type.
+func fieldAttributes(entity, field string, def map[string]any) []attribute {
+	fieldType, _ := def["type"].(string)
+	attr := func(name, goType string) attribute {
+		return attribute{name: name, goType: goType, typeName: fieldName(name)}
+	}
+	hasOptions := func() bool {
+		options, _ := def["options"].([]any)
+		return len(options) > 0
+	}
+
+	switch fieldType {
+	case "int", "autoincrement":
+		return []attribute{attr(field, "int")}
+	case "float":
+		return []attribute{attr(field, "float64")}
+	case "bool":
+		return []attribute{attr(field, "bool")}
+	case "currency":
+		return []attribute{attr(field, "float64"), attr(field+"Currency", "string")}
+	case "enum":
+		if hasOptions() {
+			return []attribute{attr(field, enumTypeName(entity, field))}
+		}
+		return []attribute{attr(field, "string")}
+	case "multiEnum", "checklist":
+		if hasOptions() {
+			return []attribute{attr(field, "[]"+enumTypeName(entity, field))}
+		}
+		return []attribute{attr(field, "[]string")}
+	case "array":
+		return []attribute{attr(field, "[]string")}
+	case "date":
+		return []attribute{attr(field, "*espoclient.Date")}
+	case "datetime":
+		return []attribute{attr(field, "*espoclient.DateTime")}
+	case "datetimeOptional":
+		// Set either field, with a time, or fieldDate for an all-day value.
+		return []attribute{attr(field, "*espoclient.DateTime"), attr(field+"Date", "*espoclient.Date")}
+	case "jsonObject":
+		return []attribute{attr(field, "map[string]any")}
+	case "jsonArray":
+		return []attribute{attr(field, "[]any")}
+	case "link", "file", "image":
+		return []attribute{attr(field+"Id", "string"), attr(field+"Name", "string")}
+	case "linkParent":
+		return []attribute{attr(field+"Id", "string"), attr(field+"Type", "string"), attr(field+"Name", "string")}
+	case "linkMultiple", "attachmentMultiple":
+		return []attribute{attr(field+"Ids", "[]string"), attr(field+"Names", "map[string]string")}
+	case "email":
+		return []attribute{attr(field, "string"), attr(field+"Data", "[]map[string]any")}
+	case "phone":
+		return []attribute{attr(field, "string"), attr(field+"Data", "[]map[string]any")}
+	case "address":
+		var attrs []attribute
+		for _, part := range []string{"Street", "City", "State", "Country", "PostalCode"} {
+			attrs = append(attrs, attr(field+part, "string"))
+		}
+		return attrs
+	case "personName":
+		attrs := []attribute{attr(field, "string")}
+		for _, part := range []string{"salutation", "first", "last", "middle"} {
+			attrs = append(attrs, attr(part+strings.ToUpper(field[:1])+field[1:], "string"))
+		}
+		return attrs
+	case "foreign", "":
+		return []attribute{attr(field, "any")}
+	}
+	// varchar, text, wysiwyg, url, number, ...
+	return []attribute{attr(field, "string")}
+}
+
+// fieldName returns the Go field name of an attribute, spelling the ID suffixes of
+// link attributes (accountId, teamsIds) as Go initialisms.
+func fieldName(attr string) string {
+	name := goName(attr)
+	switch {
+	case strings.HasSuffix(name, "Ids"):
+		return strings.TrimSuffix(name, "Ids") + "IDs"
+	case strings.HasSuffix(name, "Id"):
+		return strings.TrimSuffix(name, "Id") + "ID"
+	}
+	return name
+}
+
+// links returns the names of the entity's enabled links, sorted.
+func (g *generator) links(entity string) []string {
+	defs, _ := g.metadata["entityDefs"].(map[string]any)
+	def, _ := defs[entity].(map[string]any)
+	links, _ := def["links"].(map[string]any)
+
+	var names []string
+	for _, name := range sortedKeys(links) {
+		if l, ok := links[name].(map[string]any); ok && l["disabled"] != true {
+			names = append(names, name)
+		}
+	}
+	return names
+}
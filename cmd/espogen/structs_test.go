@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateDateFields(t *testing.T) {
+	g := &generator{
+		pkg: "espo",
+		metadata: map[string]any{
+			"scopes": map[string]any{"Call": map[string]any{"entity": true}},
+			"entityDefs": map[string]any{"Call": map[string]any{"fields": map[string]any{
+				"name":      map[string]any{"type": "varchar"},
+				"dateStart": map[string]any{"type": "datetimeOptional"},
+				"dueDate":   map[string]any{"type": "date"},
+				"closedAt":  map[string]any{"type": "datetime"},
+			}}},
+		},
+	}
+	src, err := g.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`import espoclient "github.com/egorsmkv/go-espo-api-client"`,
+		"DateStart     *espoclient.DateTime `json:\"dateStart,omitempty\"`",
+		"DateStartDate *espoclient.Date     `json:\"dateStartDate,omitempty\"`",
+		"DueDate       *espoclient.Date     `json:\"dueDate,omitempty\"`",
+		"ClosedAt      *espoclient.DateTime `json:\"closedAt,omitempty\"`",
+		"Name          string               `json:\"name,omitempty\"`",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code lacks %q:\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerateLinkAccessors(t *testing.T) {
+	g := &generator{
+		pkg: "espo",
+		metadata: map[string]any{
+			"scopes": map[string]any{"Task": map[string]any{"entity": true}},
+			"entityDefs": map[string]any{"Task": map[string]any{"fields": map[string]any{
+				"account": map[string]any{"type": "link"},
+				"teams":   map[string]any{"type": "linkMultiple"},
+				"parent":  map[string]any{"type": "linkParent"},
+			}}},
+		},
+	}
+	src, err := g.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"func (r *Task) AccountLink() espoclient.Link {\n\treturn espoclient.Link{ID: r.AccountID, Name: r.AccountName}\n}",
+		"func (r *Task) SetAccountLink(l espoclient.Link) {\n\tr.AccountID = l.ID\n\tr.AccountName = l.Name\n}",
+		"return espoclient.LinkMultiple{IDs: r.TeamsIDs, Names: r.TeamsNames}",
+		"return espoclient.LinkParent{ID: r.ParentID, Type: r.ParentType, Name: r.ParentName}",
+		"func (r *Task) SetParentLink(l espoclient.LinkParent) {",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code lacks %q:\n%s", want, src)
+		}
+	}
+}
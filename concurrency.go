@@ -0,0 +1,140 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// staleRecordError is the type of ErrStaleRecord, which also matches ErrConflict.
+type staleRecordError struct{}
+
+func (staleRecordError) Error() string { return "espoclient: record was modified in the meantime" }
+
+func (staleRecordError) Is(target error) bool { return target == ErrConflict }
+
+// ErrStaleRecord matches errors for updates rejected because the record changed
+// since it was read: a 409 response with the "modified" reason, which EspoCRM sends
+// for a stale versionNumber, a 412 response from a server or proxy that checks
+// If-Unmodified-Since, or a failed check of IfUnmodifiedSince. It also matches
+// ErrConflict.
+var ErrStaleRecord error = staleRecordError{}
+
+// WithVersionNumber sends version, the versionNumber attribute of the record as
+// read, with a record update. EspoCRM rejects the update with an error matching
+// ErrStaleRecord if the record was saved since; this requires optimistic concurrency
+// control to be enabled for the entity type. Other requests are unaffected.
+func WithVersionNumber(version int) RequestOption {
+	return func(o *requestOptions) {
+		o.versionNumber = &version
+	}
+}
+
+// IfUnmodifiedSince makes a record update conditional on the record's modifiedAt
+// still being modifiedAt, as read by the caller. EspoCRM does not check the
+// If-Unmodified-Since header itself, so the client reads modifiedAt before sending
+// the update and fails with an error matching ErrStaleRecord if it differs; the
+// header is sent as well, for proxies that check it. Unlike WithVersionNumber the
+// check is not atomic. Other requests are unaffected.
+func IfUnmodifiedSince(modifiedAt string) RequestOption {
+	return func(o *requestOptions) {
+		o.ifUnmodifiedSince = modifiedAt
+	}
+}
+
+// UpdateIfUnchanged is like UpdateFields, but only updates the record if it was
+// not saved since original was read: with WithVersionNumber if original has a
+// versionNumber attribute, otherwise with IfUnmodifiedSince if it has modifiedAt.
+// A stale original fails with an error matching ErrStaleRecord; a StaleRecordError
+// found with errors.As holds the record as stored when the server sends it.
+func (c *Client) UpdateIfUnchanged(ctx context.Context, entity, id string, original, updated map[string]any) (map[string]any, error) {
+	if version, ok := toFloat(original["versionNumber"]); ok {
+		ctx = ContextWithRequestOptions(ctx, WithVersionNumber(int(version)))
+	} else if modifiedAt, ok := original["modifiedAt"].(string); ok && modifiedAt != "" {
+		ctx = ContextWithRequestOptions(ctx, IfUnmodifiedSince(modifiedAt))
+	}
+	return c.UpdateFields(ctx, entity, id, original, updated)
+}
+
+// StaleRecordError is the error response to an update with a stale versionNumber.
+// Get it from an error returned by the client with errors.As.
+type StaleRecordError struct {
+	// Current is the record as stored, if the server sent it.
+	Current  map[string]any
+	Response *ResponseError
+}
+
+func (e *StaleRecordError) Error() string {
+	return "espoclient: record was modified in the meantime"
+}
+
+// Unwrap returns the error response.
+func (e *StaleRecordError) Unwrap() error {
+	return e.Response
+}
+
+// stale reports whether the response rejects a stale update.
+func (e *ResponseError) stale() bool {
+	status := e.Response.StatusCode
+	return status == http.StatusPreconditionFailed || (status == http.StatusConflict && e.ErrorMessage == "modified")
+}
+
+// staleRecordError parses the response to an update with a stale versionNumber.
+func (e *ResponseError) staleRecordError() *StaleRecordError {
+	if !e.stale() {
+		return nil
+	}
+	serr := &StaleRecordError{Response: e}
+	var current map[string]any
+	if json.Unmarshal(e.Response.Body, &current) == nil && current["id"] != nil {
+		serr.Current = current
+	}
+	return serr
+}
+
+// applyPrecondition applies WithVersionNumber and IfUnmodifiedSince to a record
+// update, returning the (possibly replaced) request data.
+func (c *Client) applyPrecondition(ctx context.Context, method, path string, data any, options *requestOptions) (any, error) {
+	if op, _, ok := recordOperation(method, path); !ok || op != OperationUpdate {
+		return data, nil
+	}
+
+	if modifiedAt := options.ifUnmodifiedSince; modifiedAt != "" {
+		recordPath, _, _ := strings.Cut(path, "?")
+		current, err := c.getObject(ContextWithRequestOptions(ctx, NoCache()), recordPath, url.Values{"select": {"modifiedAt"}})
+		if err != nil {
+			return nil, err
+		}
+		if current["modifiedAt"] != modifiedAt {
+			return nil, &EspoError{
+				Message: fmt.Sprintf("%s was modified at %v", strings.Trim(recordPath, "/"), current["modifiedAt"]),
+				Cause:   ErrStaleRecord,
+			}
+		}
+		if t, err := ParseDateTime(modifiedAt, nil); err == nil {
+			if options.headers == nil {
+				options.headers = http.Header{}
+			}
+			options.headers.Set("If-Unmodified-Since", t.Format(http.TimeFormat))
+		}
+	}
+
+	if options.versionNumber != nil {
+		record, ok := asRecord(data)
+		if !ok {
+			if record, ok = structToRecord(data); !ok {
+				return nil, &EspoError{Message: "WithVersionNumber requires a record body"}
+			}
+		}
+		copied := make(map[string]any, len(record)+1)
+		for k, v := range record {
+			copied[k] = v
+		}
+		copied["versionNumber"] = *options.versionNumber
+		data = copied
+	}
+	return data, nil
+}
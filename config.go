@@ -0,0 +1,369 @@
+package espoclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a complete client configuration, usually loaded from a YAML or TOML
+// file with LoadConfig:
+//
+//	url: https://crm.example.com
+//	api_path: /api/v1/
+//	auth:
+//	  api_key: 0123abcd
+//	  secret_key: 4567ef
+//	timeout: 30s
+//	retry:
+//	  max_attempts: 3
+//	  backoff: exponential
+//	  delay: 500ms
+//	  jitter: 0.5
+//	rate_limit:
+//	  requests_per_second: 10
+//	proxy:
+//	  url: socks5://127.0.0.1:1080
+//	tls:
+//	  ca_file: /etc/ssl/private-ca.pem
+//	entities:
+//	  User:
+//	    read_only: true
+type Config struct {
+	URL         string                        `yaml:"url" toml:"url"`
+	APIPath     string                        `yaml:"api_path" toml:"api_path"` // "/api/v1/" by default
+	Auth        AuthConfig                    `yaml:"auth" toml:"auth"`
+	Timeout     Duration                      `yaml:"timeout" toml:"timeout"`
+	MaxBodySize int64                         `yaml:"max_body_size" toml:"max_body_size"`
+	Retry       *RetryConfig                  `yaml:"retry" toml:"retry"`
+	RateLimit   *RateLimitConfig              `yaml:"rate_limit" toml:"rate_limit"`
+	Proxy       *ProxyConfig                  `yaml:"proxy" toml:"proxy"`
+	TLS         *TLSConfig                    `yaml:"tls" toml:"tls"`
+	Entities    map[string]EntityPolicyConfig `yaml:"entities" toml:"entities"`
+}
+
+// AuthConfig holds the credentials. HMAC (api_key with secret_key), a plain API key
+// and Basic authentication (username with password) are mutually exclusive.
+type AuthConfig struct {
+	APIKey    string `yaml:"api_key" toml:"api_key"`
+	SecretKey string `yaml:"secret_key" toml:"secret_key"`
+	Username  string `yaml:"username" toml:"username"`
+	Password  string `yaml:"password" toml:"password"`
+}
+
+// RetryConfig configures the RetryPolicy. With backoff "exponential", delay is the
+// first pause and max_delay the upper bound (see ExponentialBackoff).
+type RetryConfig struct {
+	MaxAttempts   int      `yaml:"max_attempts" toml:"max_attempts"`
+	Backoff       string   `yaml:"backoff" toml:"backoff"` // "constant" (default) or "exponential"
+	Delay         Duration `yaml:"delay" toml:"delay"`
+	MaxDelay      Duration `yaml:"max_delay" toml:"max_delay"`
+	Jitter        float64  `yaml:"jitter" toml:"jitter"`
+	MaxRetryAfter Duration `yaml:"max_retry_after" toml:"max_retry_after"`
+	Methods       []string `yaml:"methods" toml:"methods"`
+}
+
+// RateLimitConfig configures the client-side RateLimit.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second" toml:"requests_per_second"`
+	Burst             int     `yaml:"burst" toml:"burst"`
+	FailFast          bool    `yaml:"fail_fast" toml:"fail_fast"`
+}
+
+// ProxyConfig routes requests through an http, https or socks5 proxy.
+type ProxyConfig struct {
+	URL string `yaml:"url" toml:"url"`
+}
+
+// TLSConfig configures the TLS connection to the server.
+type TLSConfig struct {
+	CAFile             string `yaml:"ca_file" toml:"ca_file"`
+	CertFile           string `yaml:"cert_file" toml:"cert_file"`
+	KeyFile            string `yaml:"key_file" toml:"key_file"`
+	ServerName         string `yaml:"server_name" toml:"server_name"`
+	MinVersion         string `yaml:"min_version" toml:"min_version"` // "1.2" or "1.3"
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify" toml:"insecure_skip_verify"`
+}
+
+// EntityPolicyConfig configures the EntityPolicy of one entity type.
+type EntityPolicyConfig struct {
+	ReadOnly bool     `yaml:"read_only" toml:"read_only"`
+	Timeout  Duration `yaml:"timeout" toml:"timeout"`
+}
+
+// Duration is a time.Duration written as a string such as "30s" in configuration files.
+type Duration time.Duration
+
+// UnmarshalText parses a duration such as "500ms" or "1m30s".
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q (e.g. \"30s\")", text)
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalText formats the duration like time.Duration.String.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// ConfigError describes one problem found when validating a Config.
+type ConfigError struct {
+	Field   string // path of the field in the configuration file, e.g. "retry.max_attempts"
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("espoclient: invalid config %s: %s", e.Field, e.Message)
+}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// LoadConfig reads and validates a configuration file. The format is chosen by the
+// extension: .yaml or .yml for YAML, .toml for TOML. Unknown fields are errors, so
+// typos do not silently fall back to defaults.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &EspoError{Message: "failed to read config file", Cause: err}
+	}
+
+	cfg := &Config{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, &EspoError{Message: "failed to parse " + path, Cause: err}
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return nil, &EspoError{Message: "failed to parse " + path, Cause: err}
+		}
+		var errs []error
+		for _, key := range md.Undecoded() {
+			errs = append(errs, &ConfigError{Field: key.String(), Message: "unknown field"})
+		}
+		if len(errs) > 0 {
+			return nil, &EspoError{Message: "invalid config file " + path, Cause: errors.Join(errs...)}
+		}
+	default:
+		return nil, &EspoError{Message: fmt.Sprintf("unsupported config file extension %q (use .yaml, .yml or .toml)", ext)}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, &EspoError{Message: "invalid config file " + path, Cause: err}
+	}
+	return cfg, nil
+}
+
+// Validate checks the configuration and reports every problem found, joined with
+// errors.Join; each is a *ConfigError.
+func (cfg *Config) Validate() error {
+	var errs []error
+	invalid := func(field, format string, args ...any) {
+		errs = append(errs, &ConfigError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if cfg.URL == "" {
+		invalid("url", "is required")
+	} else if u, err := url.Parse(cfg.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		invalid("url", "%q is not an absolute URL", cfg.URL)
+	}
+	if _, err := url.Parse(cfg.APIPath); err != nil {
+		invalid("api_path", "%q is not a valid path", cfg.APIPath)
+	}
+
+	auth := cfg.Auth
+	switch {
+	case auth.SecretKey != "" && auth.APIKey == "":
+		invalid("auth.secret_key", "requires auth.api_key")
+	case auth.APIKey != "" && (auth.Username != "" || auth.Password != ""):
+		invalid("auth", "api_key cannot be combined with username and password")
+	case auth.Username != "" && auth.Password == "":
+		invalid("auth.password", "is required with auth.username")
+	case auth.Password != "" && auth.Username == "":
+		invalid("auth.username", "is required with auth.password")
+	}
+
+	if cfg.Timeout < 0 {
+		invalid("timeout", "must not be negative")
+	}
+	if cfg.MaxBodySize < 0 {
+		invalid("max_body_size", "must not be negative")
+	}
+
+	if r := cfg.Retry; r != nil {
+		if r.MaxAttempts < 1 {
+			invalid("retry.max_attempts", "must be at least 1")
+		}
+		if r.Backoff != "" && r.Backoff != "constant" && r.Backoff != "exponential" {
+			invalid("retry.backoff", "%q is not supported (use \"constant\" or \"exponential\")", r.Backoff)
+		}
+		if r.Delay < 0 {
+			invalid("retry.delay", "must not be negative")
+		}
+		if r.MaxDelay < 0 {
+			invalid("retry.max_delay", "must not be negative")
+		}
+		if r.Jitter < 0 || r.Jitter > 1 {
+			invalid("retry.jitter", "must be between 0 and 1")
+		}
+		if r.MaxRetryAfter < 0 {
+			invalid("retry.max_retry_after", "must not be negative")
+		}
+		for i, method := range r.Methods {
+			if !validMethods[strings.ToUpper(method)] {
+				invalid(fmt.Sprintf("retry.methods[%d]", i), "unknown HTTP method %q", method)
+			}
+		}
+	}
+
+	if rl := cfg.RateLimit; rl != nil {
+		if rl.RequestsPerSecond <= 0 {
+			invalid("rate_limit.requests_per_second", "must be positive")
+		}
+		if rl.Burst < 0 {
+			invalid("rate_limit.burst", "must not be negative")
+		}
+	}
+
+	if p := cfg.Proxy; p != nil {
+		u, err := url.Parse(p.URL)
+		switch {
+		case p.URL == "":
+			invalid("proxy.url", "is required")
+		case err != nil || u.Host == "":
+			invalid("proxy.url", "%q is not an absolute URL", p.URL)
+		case u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5":
+			invalid("proxy.url", "unsupported scheme %q (use http, https or socks5)", u.Scheme)
+		}
+	}
+
+	if t := cfg.TLS; t != nil {
+		if (t.CertFile == "") != (t.KeyFile == "") {
+			invalid("tls", "cert_file and key_file must be set together")
+		}
+		if _, ok := tlsVersions[t.MinVersion]; t.MinVersion != "" && !ok {
+			invalid("tls.min_version", "%q is not supported (use \"1.2\" or \"1.3\")", t.MinVersion)
+		}
+	}
+
+	for entity, policy := range cfg.Entities {
+		if policy.Timeout < 0 {
+			invalid("entities."+entity+".timeout", "must not be negative")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewClientFromConfig creates a client from a validated configuration.
+func NewClientFromConfig(cfg *Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, &EspoError{Message: "invalid configuration", Cause: err}
+	}
+
+	c, err := NewClient(cfg.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.APIPath != "" {
+		c.SetAPIPath(cfg.APIPath)
+	}
+
+	switch auth := cfg.Auth; {
+	case auth.APIKey != "":
+		c.SetApiKey(auth.APIKey)
+		if auth.SecretKey != "" {
+			c.SetSecretKey(auth.SecretKey)
+		}
+	case auth.Username != "":
+		c.SetUsernameAndPassword(auth.Username, auth.Password)
+	}
+
+	if cfg.Timeout > 0 {
+		c.SetTimeout(time.Duration(cfg.Timeout))
+	}
+	if cfg.MaxBodySize > 0 {
+		c.SetMaxRequestBodySize(cfg.MaxBodySize)
+	}
+	if r := cfg.Retry; r != nil {
+		methods := make([]string, len(r.Methods))
+		for i, method := range r.Methods {
+			methods[i] = strings.ToUpper(method)
+		}
+		policy := RetryPolicy{
+			MaxAttempts:   r.MaxAttempts,
+			Delay:         time.Duration(r.Delay),
+			Jitter:        r.Jitter,
+			MaxRetryAfter: time.Duration(r.MaxRetryAfter),
+			Methods:       methods,
+		}
+		if r.Backoff == "exponential" {
+			policy.Backoff = ExponentialBackoff{Initial: time.Duration(r.Delay), Max: time.Duration(r.MaxDelay)}
+		}
+		c.SetRetryPolicy(policy)
+	}
+	if rl := cfg.RateLimit; rl != nil {
+		c.SetRateLimit(RateLimit{RequestsPerSecond: rl.RequestsPerSecond, Burst: rl.Burst, FailFast: rl.FailFast})
+	}
+	if p := cfg.Proxy; p != nil {
+		proxyURL, _ := url.Parse(p.URL) // validated above
+		c.SetProxyURL(proxyURL)
+	}
+	if t := cfg.TLS; t != nil {
+		tlsConfig, err := t.build()
+		if err != nil {
+			return nil, err
+		}
+		c.SetTLSConfig(tlsConfig)
+	}
+	for entity, policy := range cfg.Entities {
+		c.SetEntityPolicy(entity, EntityPolicy{ReadOnly: policy.ReadOnly, Timeout: time.Duration(policy.Timeout)})
+	}
+	return c, nil
+}
+
+// build loads the certificates referenced by the configuration.
+func (t *TLSConfig) build() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         t.ServerName,
+		MinVersion:         tlsVersions[t.MinVersion],
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, &EspoError{Message: "failed to read tls.ca_file", Cause: err}
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, &EspoError{Message: "tls.ca_file " + t.CAFile + " contains no PEM certificates"}
+		}
+	}
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, &EspoError{Message: "failed to load tls.cert_file and tls.key_file", Cause: err}
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
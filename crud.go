@@ -0,0 +1,110 @@
+package espoclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// EntityList is one page of records from a list endpoint.
+type EntityList struct {
+	Total int // total number of matching records; negative if the server did not count them
+	List  []map[string]any
+}
+
+// CreateEntity creates a record and returns it as stored by the server.
+// A duplicate rejected by the server's duplicate check fails with an error
+// matching ErrConflict.
+func (c *Client) CreateEntity(ctx context.Context, entity string, data any) (map[string]any, error) {
+	return c.writeEntity(ctx, MethodPost, entity, data)
+}
+
+// ReadEntity fetches a record by ID. A missing record fails with an error matching ErrNotFound.
+func (c *Client) ReadEntity(ctx context.Context, entity, id string) (map[string]any, error) {
+	var record map[string]any
+	if err := c.getRecord(ctx, entity, id, &record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// UpdateEntity updates the given attributes of a record and returns it as stored.
+// A missing record fails with an error matching ErrNotFound, a conflicting
+// concurrent modification with one matching ErrConflict. Pass WithVersionNumber
+// or IfUnmodifiedSince in ctx (see ContextWithRequestOptions), or use
+// UpdateIfUnchanged, to reject updates of records modified since they were read.
+func (c *Client) UpdateEntity(ctx context.Context, entity, id string, data any) (map[string]any, error) {
+	return c.writeEntity(ctx, MethodPut, entity+"/"+url.PathEscape(id), data)
+}
+
+// UpdateFields sends only the attributes of updated that differ from original,
+// with PATCH, so fields that were not fetched or changed are never overwritten.
+// original is the record as read, updated the caller's modified copy. It returns
+// the record as stored, or original without sending a request if nothing changed.
+func (c *Client) UpdateFields(ctx context.Context, entity, id string, original, updated map[string]any) (map[string]any, error) {
+	changes := ChangedFields(original, updated)
+	if len(changes) == 0 {
+		return original, nil
+	}
+	return c.writeEntity(ctx, MethodPatch, entity+"/"+url.PathEscape(id), changes)
+}
+
+// ChangedFields returns the attributes of updated that are missing from original
+// or have a different value. Values are compared by their JSON encoding, so
+// numbers decoded as float64 equal the same ints.
+func ChangedFields(original, updated map[string]any) map[string]any {
+	changes := map[string]any{}
+	for key, value := range updated {
+		old, ok := original[key]
+		if !ok {
+			changes[key] = value
+			continue
+		}
+		a, errA := json.Marshal(old)
+		b, errB := json.Marshal(value)
+		if errA != nil || errB != nil || !bytes.Equal(a, b) {
+			changes[key] = value
+		}
+	}
+	return changes
+}
+
+// DeleteEntity deletes a record. A missing record fails with an error matching ErrNotFound.
+func (c *Client) DeleteEntity(ctx context.Context, entity, id string) error {
+	_, err := c.request(ctx, MethodDelete, entity+"/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+// ListEntities returns one page of the records matching params, starting at offset.
+// The page size is params.MaxSize, or 200 if unset.
+func (c *Client) ListEntities(ctx context.Context, entity string, params *SearchParams, offset int) (*EntityList, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	page, err := c.listPage(ctx, entity, params.Values(), offset, params.pageSize())
+	if err != nil {
+		return nil, err
+	}
+	list := &EntityList{Total: page.Total, List: make([]map[string]any, 0, len(page.List))}
+	for _, raw := range page.List {
+		var record map[string]any
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, &EspoError{Message: "failed to parse record", Cause: err}
+		}
+		list.List = append(list.List, record)
+	}
+	return list, nil
+}
+
+func (c *Client) writeEntity(ctx context.Context, method, path string, data any) (map[string]any, error) {
+	resp, err := c.request(ctx, method, path, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	var record map[string]any
+	if err := resp.GetParsedBody(&record); err != nil {
+		return nil, &EspoError{Message: "failed to parse record", Cause: err}
+	}
+	return record, nil
+}
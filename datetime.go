@@ -0,0 +1,200 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Layouts EspoCRM uses for date and datetime attributes.
+// Datetimes are always stored in UTC; dates have no time zone.
+const (
+	DateFormat     = "2006-01-02"
+	DateTimeFormat = "2006-01-02 15:04:05"
+)
+
+// FormatDateTime converts t to UTC and formats it the way EspoCRM stores datetimes.
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format(DateTimeFormat)
+}
+
+// ParseDateTime parses an EspoCRM datetime value (always UTC) and returns it in loc.
+// A nil loc returns the time in UTC.
+func ParseDateTime(s string, loc *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(DateTimeFormat, s, time.UTC)
+	if err != nil {
+		// Some endpoints omit the seconds.
+		var shortErr error
+		t, shortErr = time.ParseInLocation("2006-01-02 15:04", s, time.UTC)
+		if shortErr != nil {
+			return time.Time{}, fmt.Errorf("invalid datetime %q: %w", s, err)
+		}
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t, nil
+}
+
+// FormatDate returns the calendar date of t as seen in loc, formatted for a date attribute.
+// Use the user's location (see UserLocation) so that, for example, 23:30 local time
+// is not stored as the following day.
+func FormatDate(t time.Time, loc *time.Location) string {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t.Format(DateFormat)
+}
+
+// ParseDate parses an EspoCRM date value and returns midnight of that day in loc.
+// A nil loc uses UTC.
+func ParseDate(s string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation(DateFormat, s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// UserLocation returns the time zone of the authenticated user, read from App/user.
+// The user's preference wins; otherwise the system setting is used, and UTC if neither is set.
+func (c *Client) UserLocation(ctx context.Context) (*time.Location, error) {
+	resp, err := c.request(ctx, MethodGet, "App/user", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info struct {
+		Preferences struct {
+			TimeZone string `json:"timeZone"`
+		} `json:"preferences"`
+		Settings struct {
+			TimeZone string `json:"timeZone"`
+		} `json:"settings"`
+	}
+	if err := resp.GetParsedBody(&info); err != nil {
+		return nil, &EspoError{Message: "failed to parse App/user response", Cause: err}
+	}
+
+	name := info.Preferences.TimeZone
+	if name == "" {
+		name = info.Settings.TimeZone
+	}
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, &EspoError{Message: fmt.Sprintf("unknown time zone %q", name), Cause: err}
+	}
+	return loc, nil
+}
+
+// Date is a date attribute in typed records. It marshals as "2006-01-02" and its
+// zero value as null, which also unmarshals (like "") to the zero value.
+type Date struct {
+	time.Time // midnight UTC of the date
+}
+
+// NewDate returns the calendar date of t as seen in loc (UTC if nil), as FormatDate does.
+func NewDate(t time.Time, loc *time.Location) Date {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// String formats the date as EspoCRM does, or returns "" for the zero value.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DateFormat)
+}
+
+// In returns midnight of the date in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return marshalTimeString(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalTimeString(data)
+	if err != nil || s == "" {
+		*d = Date{}
+		return err
+	}
+	t, err := ParseDate(s, time.UTC)
+	if err != nil {
+		return err
+	}
+	*d = Date{t}
+	return nil
+}
+
+// DateTime is a datetime attribute in typed records. It is held in UTC, as EspoCRM
+// stores it; use In with the user's location (see UserLocation) to display it. It
+// marshals as "2006-01-02 15:04:05" and its zero value as null, which also
+// unmarshals (like "") to the zero value.
+type DateTime struct {
+	time.Time
+}
+
+// NewDateTime returns t as a DateTime, truncated to seconds.
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{t.UTC().Truncate(time.Second)}
+}
+
+// String formats the datetime as EspoCRM does, or returns "" for the zero value.
+func (t DateTime) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return FormatDateTime(t.Time)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	return marshalTimeString(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *DateTime) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalTimeString(data)
+	if err != nil || s == "" {
+		*t = DateTime{}
+		return err
+	}
+	parsed, err := ParseDateTime(s, time.UTC)
+	if err != nil {
+		return err
+	}
+	*t = DateTime{parsed}
+	return nil
+}
+
+func marshalTimeString(s string) ([]byte, error) {
+	if s == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s)
+}
+
+// unmarshalTimeString decodes a JSON string or null (returned as "").
+func unmarshalTimeString(data []byte) (string, error) {
+	if string(data) == "null" {
+		return "", nil
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	return s, err
+}
@@ -0,0 +1,115 @@
+package espoclient
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNoDefaultClient is returned by the package-level helpers when SetDefault has
+// not been called.
+var ErrNoDefaultClient = errors.New("espoclient: no default client; call SetDefault first")
+
+var defaultClient atomic.Pointer[Client]
+
+// SetDefault sets the client used by the package-level helpers (Request,
+// RequestWithContext, Do, Get, Create, Update, Delete and List). It is safe to
+// call concurrently with them; passing nil clears the default client.
+func SetDefault(c *Client) {
+	defaultClient.Store(c)
+}
+
+// Default returns the client set with SetDefault, or nil.
+func Default() *Client {
+	return defaultClient.Load()
+}
+
+func mustDefault() (*Client, error) {
+	c := Default()
+	if c == nil {
+		return nil, ErrNoDefaultClient
+	}
+	return c, nil
+}
+
+// records returns a map-based service for entity on the default client.
+func records(entity string) (*EntityService[map[string]any], error) {
+	c, err := mustDefault()
+	if err != nil {
+		return nil, err
+	}
+	return NewEntityService[map[string]any](c, entity), nil
+}
+
+// Request sends a request with the default client (see Client.Request).
+func Request(method, path string, data any, headers map[string]string, opts ...RequestOption) (*Response, error) {
+	c, err := mustDefault()
+	if err != nil {
+		return nil, err
+	}
+	return c.Request(method, path, data, headers, opts...)
+}
+
+// RequestWithContext sends a request bound to ctx with the default client
+// (see Client.RequestWithContext).
+func RequestWithContext(ctx context.Context, method, path string, data any, headers map[string]string, opts ...RequestOption) (*Response, error) {
+	c, err := mustDefault()
+	if err != nil {
+		return nil, err
+	}
+	return c.RequestWithContext(ctx, method, path, data, headers, opts...)
+}
+
+// Do sends a request described by spec with the default client (see Client.Do).
+func Do(ctx context.Context, spec *RequestSpec) (*Response, error) {
+	c, err := mustDefault()
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, spec)
+}
+
+// Get fetches a record by ID with the default client.
+func Get(ctx context.Context, entity, id string) (map[string]any, error) {
+	s, err := records(entity)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+// Create creates a record with the default client and returns it as stored.
+func Create(ctx context.Context, entity string, data map[string]any) (map[string]any, error) {
+	s, err := records(entity)
+	if err != nil {
+		return nil, err
+	}
+	return s.Create(ctx, data)
+}
+
+// Update updates a record with the default client and returns it as stored.
+func Update(ctx context.Context, entity, id string, data map[string]any) (map[string]any, error) {
+	s, err := records(entity)
+	if err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, id, data)
+}
+
+// Delete deletes a record with the default client.
+func Delete(ctx context.Context, entity, id string) error {
+	s, err := records(entity)
+	if err != nil {
+		return err
+	}
+	return s.Delete(ctx, id)
+}
+
+// List returns all records matching params with the default client.
+func List(ctx context.Context, entity string, params *SearchParams) ([]map[string]any, error) {
+	s, err := records(entity)
+	if err != nil {
+		return nil, err
+	}
+	return s.List(ctx, params)
+}
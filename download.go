@@ -0,0 +1,44 @@
+package espoclient
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/url"
+)
+
+// Download describes a file written by DownloadAttachment.
+type Download struct {
+	ContentType string
+	FileName    string // from the Content-Disposition header; empty if absent
+	Size        int64  // number of bytes written
+}
+
+// DownloadAttachment writes the contents of an attachment to w as they arrive
+// (GET Attachment/file/{id}), so large files are never held in memory.
+// If an error occurs mid-transfer, w may have received part of the file.
+func (c *Client) DownloadAttachment(ctx context.Context, id string, w io.Writer) (*Download, error) {
+	counter := &countingWriter{w: w}
+	resp, err := c.request(ctx, MethodGet, "Attachment/file/"+url.PathEscape(id), nil, nil, WithOutput(counter))
+	if err != nil {
+		return nil, err
+	}
+
+	download := &Download{ContentType: resp.ContentType, Size: counter.n}
+	if _, params, err := mime.ParseMediaType(resp.Headers.Get("Content-Disposition")); err == nil {
+		download.FileName = params["filename"]
+	}
+	return download, nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
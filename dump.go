@@ -0,0 +1,144 @@
+package espoclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// RequestDump is a capture of one request attempt and its response, made when
+// dumping is enabled with SetDumpFunc. Credentials in headers are masked.
+type RequestDump struct {
+	Attempt        int
+	Method         string
+	URL            string
+	RequestHeaders http.Header
+	RequestBody    []byte
+	// StringToSign is the string signed for HMAC authentication, empty otherwise.
+	// A 403 with HMAC usually means the server computed a different one.
+	StringToSign string
+
+	StatusCode      int // 0 if no response was received
+	ResponseHeaders http.Header
+	// ResponseBody is empty for responses streamed with WithOutput or RequestStream.
+	ResponseBody []byte
+	Err          error
+}
+
+// String formats the dump as the raw HTTP exchange.
+func (d *RequestDump) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s (attempt %d)\n", d.Method, d.URL, d.Attempt)
+	if d.StringToSign != "" {
+		fmt.Fprintf(&b, "HMAC string to sign: %q\n", d.StringToSign)
+	}
+	writeDumpHeaders(&b, d.RequestHeaders)
+	if len(d.RequestBody) > 0 {
+		fmt.Fprintf(&b, "\n%s\n", d.RequestBody)
+	}
+	b.WriteString("\n")
+	if d.StatusCode != 0 {
+		fmt.Fprintf(&b, "%d %s\n", d.StatusCode, http.StatusText(d.StatusCode))
+		writeDumpHeaders(&b, d.ResponseHeaders)
+		if len(d.ResponseBody) > 0 {
+			fmt.Fprintf(&b, "\n%s\n", d.ResponseBody)
+		}
+	}
+	if d.Err != nil {
+		fmt.Fprintf(&b, "error: %v\n", d.Err)
+	}
+	return b.String()
+}
+
+func writeDumpHeaders(b *strings.Builder, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range h[name] {
+			fmt.Fprintf(b, "%s: %s\n", name, value)
+		}
+	}
+}
+
+// DumpFunc receives the dump of every request attempt.
+type DumpFunc func(*RequestDump)
+
+// SetDumpFunc captures every request attempt, with its headers, body and response,
+// and passes it to fn, e.g. to log it:
+//
+//	client.SetDumpFunc(func(d *espoclient.RequestDump) { log.Print(d) })
+//
+// The dump of the last attempt is also available in Response.Dump, including the
+// Response of a ResponseError. Request bodies are read into memory to capture
+// them, so enable it for debugging only. Passing nil disables it.
+func (c *Client) SetDumpFunc(fn DumpFunc) *Client {
+	c.dump = fn
+	return c
+}
+
+// newDump captures the request part of a dump. A body that cannot be rewound is
+// read and replaced by the buffered copy.
+func (c *Client) newDump(req *http.Request, attempt int, options *requestOptions) (*RequestDump, error) {
+	d := &RequestDump{
+		Attempt:        attempt,
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestHeaders: maskHeaders(req.Header),
+		StringToSign:   options.stringToSign,
+	}
+	switch {
+	case req.Body == nil || req.Body == http.NoBody:
+	case req.GetBody != nil:
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, &EspoError{Message: "failed to read request body", Cause: err}
+		}
+		defer body.Close()
+		if d.RequestBody, err = io.ReadAll(body); err != nil {
+			return nil, &EspoError{Message: "failed to read request body", Cause: err}
+		}
+	default:
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, &EspoError{Message: "failed to read request body", Cause: err}
+		}
+		d.RequestBody = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+	return d, nil
+}
+
+// finishDump adds the result of the attempt to d and reports it.
+func (c *Client) finishDump(d *RequestDump, resp *Response, err error) {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		resp = respErr.Response
+	}
+	if resp != nil {
+		d.StatusCode = resp.StatusCode
+		d.ResponseHeaders = maskHeaders(resp.Headers)
+		d.ResponseBody = resp.Body
+		resp.Dump = d
+	}
+	d.Err = err
+	c.dump(d)
+}
+
+// maskHeaders returns a copy of h with credentials masked.
+func maskHeaders(h http.Header) http.Header {
+	masked := h.Clone()
+	for name := range masked {
+		if sensitiveHeaders[name] {
+			masked[name] = []string{"[REDACTED]"}
+		}
+	}
+	return masked
+}
@@ -0,0 +1,82 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// SkipDuplicateCheck creates or updates a record even if the server's duplicate
+// check finds matches (X-Skip-Duplicate-Check header).
+func SkipDuplicateCheck() RequestOption {
+	return WithHeader("X-Skip-Duplicate-Check", "true")
+}
+
+// FindPossibleDuplicates returns existing records of the entity that look like
+// duplicates of data, so import tools can decide before creating anything. It is
+// a client-side heuristic, not the server's duplicate check, which only runs when
+// a record is created (see DuplicatesFromError). A record matches on:
+//   - the same name, or the same last name and, if given, first name;
+//   - the same email address, primary or not, ignoring case;
+//   - the same phone number digits, which the server's default check ignores.
+func (c *Client) FindPossibleDuplicates(ctx context.Context, entity string, data map[string]any) ([]map[string]any, error) {
+	str := func(key string) string {
+		s, _ := data[key].(string)
+		return s
+	}
+
+	var conditions []WhereItem
+	if last := str("lastName"); last != "" {
+		// A first name alone is too common to match on.
+		name := []WhereItem{Equals("lastName", last)}
+		if first := str("firstName"); first != "" {
+			name = append(name, Equals("firstName", first))
+		}
+		conditions = append(conditions, And(name...))
+	} else if name := str("name"); name != "" {
+		conditions = append(conditions, Equals("name", name))
+	}
+	if email := NormalizeEmail(str("emailAddress")); email != "" {
+		conditions = append(conditions, Equals("emailAddresses.lower", email))
+	}
+	phone := str("phoneNumber")
+	if c.normalizer != nil {
+		phone = c.normalizer.phone(phone)
+	}
+	if digits := onlyDigits(phone); digits != "" {
+		conditions = append(conditions, Equals("phoneNumbers.numeric", digits))
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	return c.listAll(ctx, entity, &SearchParams{Where: []WhereItem{Or(conditions...)}})
+}
+
+// DuplicatesFromError returns the existing records reported when a create or update
+// was rejected by the server's duplicate check (409 Conflict), and false for other errors.
+func DuplicatesFromError(err error) ([]map[string]any, bool) {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.Response.StatusCode != http.StatusConflict {
+		return nil, false
+	}
+	var list []map[string]any
+	if json.Unmarshal(respErr.Response.Body, &list) == nil {
+		return list, true
+	}
+	var wrapped struct {
+		Data []map[string]any `json:"data"`
+	}
+	if json.Unmarshal(respErr.Response.Body, &wrapped) == nil && wrapped.Data != nil {
+		return wrapped.Data, true
+	}
+	return nil, false
+}
+
+// GetCopyAttributes returns the attributes for a new record copied from an
+// existing one, as prefilled by the Duplicate action of the UI. It has nothing to
+// do with duplicate detection.
+func (c *Client) GetCopyAttributes(ctx context.Context, entity, id string) (map[string]any, error) {
+	return c.getObject(ctx, entity+"/action/getDuplicateAttributes", url.Values{"id": {id}})
+}
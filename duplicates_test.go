@@ -0,0 +1,45 @@
+package espoclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestFindPossibleDuplicatesSkipsEmptyNameParts(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, _ := url.QueryUnescape(r.URL.RawQuery)
+		queries = append(queries, query)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":0,"list":[]}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.FindPossibleDuplicates(ctx, "Contact", map[string]any{"firstName": "John"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 0 {
+		t.Fatalf("a lone first name was searched for: %q", queries)
+	}
+
+	if _, err := client.FindPossibleDuplicates(ctx, "Contact", map[string]any{"firstName": "John", "emailAddress": "John@Example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 1 {
+		t.Fatalf("got %d requests, want 1", len(queries))
+	}
+	if q := queries[0]; strings.Contains(q, "Name") || !strings.Contains(q, "john@example.com") {
+		t.Errorf("query = %q, want only the normalized email address", q)
+	}
+}
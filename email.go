@@ -0,0 +1,79 @@
+package espoclient
+
+import (
+	"context"
+	"strings"
+)
+
+// EmailMessage is an email sent with SendEmail. Files are uploaded as attachments of
+// the email; AttachmentsIDs references attachments uploaded beforehand.
+type EmailMessage struct {
+	From    string // sender address; the user's default if empty
+	To      []string
+	Cc      []string
+	Bcc     []string
+	Subject string
+	Body    string
+	IsHTML  bool // Body is HTML rather than plain text
+
+	Files          []NoteFile
+	AttachmentsIDs []string
+
+	// ParentType and ParentID relate the email to a record, e.g. an Account.
+	ParentType string
+	ParentID   string
+}
+
+// SentEmail identifies an email sent with SendEmail.
+type SentEmail struct {
+	ID        string `json:"id"`        // ID of the Email record
+	MessageID string `json:"messageId"` // Message-ID header of the sent email
+}
+
+// SendEmail creates an Email record and sends it through the outbound email account
+// of the authenticated user (or the system's), as the Send button of EspoCRM does.
+func (c *Client) SendEmail(ctx context.Context, msg EmailMessage) (*SentEmail, error) {
+	if len(msg.To)+len(msg.Cc)+len(msg.Bcc) == 0 {
+		return nil, &EspoError{Message: "email has no recipients"}
+	}
+	ids := append([]string{}, msg.AttachmentsIDs...)
+	for _, file := range msg.Files {
+		attachmentID, err := c.UploadAttachment(ctx, file.Content, AttachmentMeta{
+			Name:        file.Name,
+			Type:        file.Type,
+			RelatedType: "Email",
+			Field:       "attachments",
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, attachmentID)
+	}
+
+	record := map[string]any{
+		"status":         "Sending",
+		"to":             strings.Join(msg.To, ";"),
+		"cc":             strings.Join(msg.Cc, ";"),
+		"bcc":            strings.Join(msg.Bcc, ";"),
+		"name":           msg.Subject,
+		"body":           msg.Body,
+		"isHtml":         msg.IsHTML,
+		"attachmentsIds": ids,
+	}
+	if msg.From != "" {
+		record["from"] = msg.From
+	}
+	if msg.ParentType != "" {
+		record["parentType"] = msg.ParentType
+		record["parentId"] = msg.ParentID
+	}
+	resp, err := c.request(ctx, MethodPost, "Email", record, nil)
+	if err != nil {
+		return nil, err
+	}
+	var sent SentEmail
+	if err := resp.GetParsedBody(&sent); err != nil {
+		return nil, &EspoError{Message: "failed to parse email", Cause: err}
+	}
+	return &sent, nil
+}
@@ -0,0 +1,83 @@
+package espoclient
+
+import "context"
+
+// enrichChunkSize is the number of IDs per "in" query, keeping URLs well below server limits.
+const enrichChunkSize = 100
+
+// Enrich attaches the records referenced by a link field to each record, replacing
+// N+1 lookups with a few list calls. For a link ("account") the referenced record is
+// read from {link}Id and stored under record[link]; for a link-multiple ("teams") the
+// IDs come from {link}Ids and a slice of records is stored. References that cannot be
+// found (deleted, no access) are left out.
+//
+// foreignEntity is the entity type the link points to; fields limits the attributes
+// fetched (all if empty).
+func (c *Client) Enrich(ctx context.Context, records []map[string]any, link, foreignEntity string, fields ...string) error {
+	var ids []string
+	seen := map[string]bool{}
+	for _, record := range records {
+		for _, id := range referencedIDs(record, link) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	byID := make(map[string]map[string]any, len(ids))
+	for start := 0; start < len(ids); start += enrichChunkSize {
+		end := min(start+enrichChunkSize, len(ids))
+		params := withSelect(&SearchParams{
+			Where: []WhereItem{{Type: "in", Attribute: "id", Value: ids[start:end]}},
+		}, fields)
+		found, err := c.listAll(ctx, foreignEntity, params)
+		if err != nil {
+			return err
+		}
+		for _, rec := range found {
+			if id, ok := rec["id"].(string); ok {
+				byID[id] = rec
+			}
+		}
+	}
+
+	for _, record := range records {
+		if id, ok := record[link+"Id"].(string); ok {
+			if rec, ok := byID[id]; ok {
+				record[link] = rec
+			}
+			continue
+		}
+		if _, ok := record[link+"Ids"]; ok {
+			related := []map[string]any{}
+			for _, id := range referencedIDs(record, link) {
+				if rec, ok := byID[id]; ok {
+					related = append(related, rec)
+				}
+			}
+			record[link] = related
+		}
+	}
+	return nil
+}
+
+// referencedIDs returns the IDs a record references through a link or link-multiple field.
+func referencedIDs(record map[string]any, link string) []string {
+	if id, ok := record[link+"Id"].(string); ok && id != "" {
+		return []string{id}
+	}
+	switch v := record[link+"Ids"].(type) {
+	case []string:
+		return v
+	case []any:
+		ids := make([]string, 0, len(v))
+		for _, id := range v {
+			if s, ok := id.(string); ok && s != "" {
+				ids = append(ids, s)
+			}
+		}
+		return ids
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Templates of custom entity types.
+const (
+	EntityTemplateBase     = "Base"
+	EntityTemplateBasePlus = "BasePlus" // with activities, history and tasks
+	EntityTemplateEvent    = "Event"    // with dates, status and attendees
+	EntityTemplatePerson   = "Person"   // with name, email, phone and address
+	EntityTemplateCompany  = "Company"  // with email, phone and billing address
+)
+
+// Types of links between entity types, from the side of the first entity type.
+const (
+	LinkTypeOneToMany        = "oneToMany"
+	LinkTypeManyToOne        = "manyToOne"
+	LinkTypeManyToMany       = "manyToMany"
+	LinkTypeOneToOneLeft     = "oneToOneLeft"
+	LinkTypeOneToOneRight    = "oneToOneRight"
+	LinkTypeChildrenToParent = "childrenToParent"
+)
+
+// EntityDefinition describes a custom entity type to create with
+// CreateCustomEntity.
+type EntityDefinition struct {
+	Name          string // e.g. "Project"
+	Template      string // EntityTemplateBase if empty
+	LabelSingular string
+	LabelPlural   string
+	Stream        bool // enables the stream and following
+	Disabled      bool
+	IconClass     string // e.g. "fas fa-folder"
+	Color         string // e.g. "#6FA8D6"
+	// Attributes are other parameters of the entity type, e.g. "kanbanViewMode".
+	Attributes map[string]any
+}
+
+// EntityDefinitionUpdate describes changes to a custom entity type for
+// UpdateCustomEntity. Nil fields are left unchanged.
+type EntityDefinitionUpdate struct {
+	LabelSingular *string
+	LabelPlural   *string
+	Stream        *bool
+	Disabled      *bool
+	IconClass     *string
+	Color         *string
+	Attributes    map[string]any
+}
+
+// LinkDefinition describes a relationship to create with CreateLink.
+type LinkDefinition struct {
+	Entity        string // e.g. "Project"
+	EntityForeign string // e.g. "Account"
+	Type          string // LinkTypeOneToMany, ...
+	Link          string // name of the link on Entity, e.g. "accounts"
+	LinkForeign   string // name of the link on EntityForeign, e.g. "projects"
+	Label         string
+	LabelForeign  string
+	// RelationName names the middle table of a many-to-many link; derived from the
+	// link names if empty.
+	RelationName string
+	Attributes   map[string]any
+}
+
+// CreateCustomEntity creates a custom entity type with the Entity Manager and
+// returns its name, which the server may prefix (e.g. "CProject" on EspoCRM 7.5
+// and later). Managing the schema requires an admin API user; the metadata
+// cache of the client is invalidated.
+func (c *Client) CreateCustomEntity(ctx context.Context, def EntityDefinition) (string, error) {
+	data := Payload{}
+	for k, v := range def.Attributes {
+		data[k] = v
+	}
+	data["name"] = def.Name
+	data["type"] = def.Template
+	if def.Template == "" {
+		data["type"] = EntityTemplateBase
+	}
+	data["labelSingular"] = def.LabelSingular
+	if def.LabelSingular == "" {
+		data["labelSingular"] = def.Name
+	}
+	data["labelPlural"] = def.LabelPlural
+	if def.LabelPlural == "" {
+		data["labelPlural"] = data["labelSingular"]
+	}
+	data["stream"] = def.Stream
+	data["disabled"] = def.Disabled
+	setIfNotEmpty(data, "iconClass", def.IconClass)
+	setIfNotEmpty(data, "color", def.Color)
+
+	raw, err := c.entityManagerAction(ctx, "createEntity", data)
+	if err != nil {
+		return "", err
+	}
+	// Older versions respond with true rather than the created name.
+	var created struct {
+		Name string `json:"name"`
+	}
+	if json.Unmarshal(raw, &created) == nil && created.Name != "" {
+		return created.Name, nil
+	}
+	return def.Name, nil
+}
+
+// UpdateCustomEntity changes the parameters of an entity type.
+func (c *Client) UpdateCustomEntity(ctx context.Context, name string, update EntityDefinitionUpdate) error {
+	data := Payload{}
+	for k, v := range update.Attributes {
+		data[k] = v
+	}
+	data["name"] = name
+	setIfNotNil(data, "labelSingular", update.LabelSingular)
+	setIfNotNil(data, "labelPlural", update.LabelPlural)
+	setIfNotNil(data, "stream", update.Stream)
+	setIfNotNil(data, "disabled", update.Disabled)
+	setIfNotNil(data, "iconClass", update.IconClass)
+	setIfNotNil(data, "color", update.Color)
+	_, err := c.entityManagerAction(ctx, "updateEntity", data)
+	return err
+}
+
+// RemoveCustomEntity removes a custom entity type. Its records are dropped.
+func (c *Client) RemoveCustomEntity(ctx context.Context, name string) error {
+	_, err := c.entityManagerAction(ctx, "removeEntity", Payload{"name": name})
+	return err
+}
+
+// CreateLink creates a relationship between two entity types.
+func (c *Client) CreateLink(ctx context.Context, def LinkDefinition) error {
+	data := Payload{}
+	for k, v := range def.Attributes {
+		data[k] = v
+	}
+	data["entity"] = def.Entity
+	data["entityForeign"] = def.EntityForeign
+	data["linkType"] = def.Type
+	data["link"] = def.Link
+	data["linkForeign"] = def.LinkForeign
+	data["label"] = def.Label
+	if def.Label == "" {
+		data["label"] = def.Link
+	}
+	data["labelForeign"] = def.LabelForeign
+	if def.LabelForeign == "" {
+		data["labelForeign"] = def.LinkForeign
+	}
+	setIfNotEmpty(data, "relationName", def.RelationName)
+	_, err := c.entityManagerAction(ctx, "createLink", data)
+	return err
+}
+
+// RemoveLink removes a custom relationship, on both sides.
+func (c *Client) RemoveLink(ctx context.Context, entity, link string) error {
+	_, err := c.entityManagerAction(ctx, "removeLink", Payload{"entity": entity, "link": link})
+	return err
+}
+
+// entityManagerAction calls an Entity Manager action and invalidates the cached
+// metadata on success.
+func (c *Client) entityManagerAction(ctx context.Context, name string, data Payload) (json.RawMessage, error) {
+	raw, err := c.Action(ctx, "EntityManager", "", name, data)
+	if err != nil {
+		return nil, err
+	}
+	c.InvalidateMetadata()
+	return raw, nil
+}
+
+// FieldDefinition describes a custom field to create with CreateCustomField.
+// The parameters that apply depend on the type.
+type FieldDefinition struct {
+	Name      string // e.g. "budget"
+	Type      string // e.g. "varchar", "int", "enum", "currency", "date"
+	Label     string
+	Required  bool
+	Default   any
+	MaxLength int      // varchar
+	Options   []string // enum, multiEnum, checklist and array
+	Tooltip   string   // tooltip text
+	// Attributes are other parameters of the field, e.g. "min", "max" or "style".
+	Attributes map[string]any
+}
+
+// CreateCustomField adds a field to an entity type with the Field Manager and
+// returns its definition. The server may prefix the name (e.g. "cBudget" on
+// EspoCRM 7.5 and later); the entityDefs metadata shows the stored name.
+func (c *Client) CreateCustomField(ctx context.Context, entity string, def FieldDefinition) (map[string]any, error) {
+	data := Payload{}
+	for k, v := range def.Attributes {
+		data[k] = v
+	}
+	data["name"] = def.Name
+	data["type"] = def.Type
+	data["label"] = def.Label
+	if def.Label == "" {
+		data["label"] = def.Name
+	}
+	if def.Required {
+		data["required"] = true
+	}
+	if def.Default != nil {
+		data["default"] = def.Default
+	}
+	if def.MaxLength > 0 {
+		data["maxLength"] = def.MaxLength
+	}
+	if def.Options != nil {
+		data["options"] = def.Options
+	}
+	if def.Tooltip != "" {
+		data["tooltip"] = true
+		data["tooltipText"] = def.Tooltip
+	}
+	return c.fieldManagerRequest(ctx, MethodPost, fieldManagerPath(entity, ""), data)
+}
+
+// GetCustomField returns the definition of a field as seen by the Field Manager.
+func (c *Client) GetCustomField(ctx context.Context, entity, field string) (map[string]any, error) {
+	return c.getObject(ctx, fieldManagerPath(entity, field), nil)
+}
+
+// UpdateCustomField changes parameters of a field, e.g. {"required": true}, and
+// returns its definition.
+func (c *Client) UpdateCustomField(ctx context.Context, entity, field string, changes map[string]any) (map[string]any, error) {
+	return c.fieldManagerRequest(ctx, MethodPut, fieldManagerPath(entity, field), changes)
+}
+
+// RemoveCustomField removes a custom field. Its values are dropped.
+func (c *Client) RemoveCustomField(ctx context.Context, entity, field string) error {
+	_, err := c.fieldManagerRequest(ctx, MethodDelete, fieldManagerPath(entity, field), nil)
+	return err
+}
+
+// fieldManagerPath returns the Field Manager path of an entity type or a field.
+func fieldManagerPath(entity, field string) string {
+	path := "Admin/fieldManager/" + url.PathEscape(entity)
+	if field != "" {
+		path += "/" + url.PathEscape(field)
+	}
+	return path
+}
+
+// fieldManagerRequest sends a Field Manager request and invalidates the cached
+// metadata on success.
+func (c *Client) fieldManagerRequest(ctx context.Context, method, path string, data any) (map[string]any, error) {
+	resp, err := c.request(ctx, method, path, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.InvalidateMetadata()
+	var def map[string]any
+	if method != MethodDelete {
+		if err := resp.GetParsedBody(&def); err != nil {
+			return nil, &EspoError{Message: "failed to parse field definition", Cause: err}
+		}
+	}
+	return def, nil
+}
+
+// RebuildSchema rebuilds the database schema and clears the server cache, as the
+// Rebuild action of the Administration does. It is needed after changes made
+// outside the Entity and Field Managers, e.g. by deploying metadata files.
+func (c *Client) RebuildSchema(ctx context.Context) error {
+	if _, err := c.request(ctx, MethodPost, "Admin/rebuild", nil, nil); err != nil {
+		return err
+	}
+	c.InvalidateMetadata()
+	return nil
+}
@@ -0,0 +1,87 @@
+package espoclient
+
+import (
+	"context"
+	"strings"
+)
+
+// EnumMapper translates enum option values to their labels in one language and back,
+// e.g. Lead.status "inProcess" <-> "In Process".
+// It holds a snapshot of metadata and translations and is safe for concurrent use.
+type EnumMapper struct {
+	metadata map[string]any
+	i18n     map[string]any
+}
+
+// NewEnumMapper builds an EnumMapper from already fetched metadata and translations.
+func NewEnumMapper(metadata, i18n map[string]any) *EnumMapper {
+	return &EnumMapper{metadata: metadata, i18n: i18n}
+}
+
+// EnumMapper returns a mapper over the cached metadata and the translations for
+// language (empty for the user's language). Keep the mapper around rather than
+// calling this per value.
+func (c *Client) EnumMapper(ctx context.Context, language string) (*EnumMapper, error) {
+	metadata, err := c.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i18n, err := c.GetI18n(ctx, language)
+	if err != nil {
+		return nil, err
+	}
+	return NewEnumMapper(metadata, i18n), nil
+}
+
+// Label returns the translated label of an enum value. If no translation exists,
+// the value itself is returned.
+func (m *EnumMapper) Label(entity, field, value string) string {
+	if label, ok := m.translations(entity, field)[value].(string); ok && label != "" {
+		return label
+	}
+	return value
+}
+
+// Value returns the raw enum value for a translated label (case-insensitive).
+// The label may also be a raw value, which is returned as-is.
+func (m *EnumMapper) Value(entity, field, label string) (string, bool) {
+	for _, option := range m.Options(entity, field) {
+		if option == label || strings.EqualFold(m.Label(entity, field, option), label) {
+			return option, true
+		}
+	}
+	for value, translated := range m.translations(entity, field) {
+		if s, ok := translated.(string); ok && strings.EqualFold(s, label) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// Options returns the enum options defined in metadata for the field.
+func (m *EnumMapper) Options(entity, field string) []string {
+	raw, _ := lookupPath(m.metadata, "entityDefs", entity, "fields", field, "options").([]any)
+	options := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			options = append(options, s)
+		}
+	}
+	return options
+}
+
+// translations returns the option label map for a field. A field's "translation"
+// definition (e.g. "Global.scopeNames") takes precedence, then {entity}.options.{field},
+// then Global.options.{field}.
+func (m *EnumMapper) translations(entity, field string) map[string]any {
+	if path, ok := lookupPath(m.metadata, "entityDefs", entity, "fields", field, "translation").(string); ok && path != "" {
+		if t, ok := lookupPath(m.i18n, strings.Split(path, ".")...).(map[string]any); ok {
+			return t
+		}
+	}
+	if t, ok := lookupPath(m.i18n, entity, "options", field).(map[string]any); ok {
+		return t
+	}
+	t, _ := lookupPath(m.i18n, "Global", "options", field).(map[string]any)
+	return t
+}
@@ -0,0 +1,134 @@
+package espoclient
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables read by NewClientFromEnv.
+const (
+	EnvURL              = "ESPO_URL"
+	EnvAPIPath          = "ESPO_API_PATH"
+	EnvAPIKey           = "ESPO_API_KEY"
+	EnvSecretKey        = "ESPO_SECRET_KEY"
+	EnvUsername         = "ESPO_USERNAME"
+	EnvPassword         = "ESPO_PASSWORD"
+	EnvTimeout          = "ESPO_TIMEOUT"
+	EnvRetryMaxAttempts = "ESPO_RETRY_MAX_ATTEMPTS"
+	EnvRetryDelay       = "ESPO_RETRY_DELAY"
+	EnvRateLimit        = "ESPO_RATE_LIMIT"
+	EnvProxyURL         = "ESPO_PROXY_URL"
+	EnvMaxBodySize      = "ESPO_MAX_BODY_SIZE"
+)
+
+// NewClientFromEnv creates a client configured from environment variables:
+//
+//	ESPO_URL                  base URL of the instance (required)
+//	ESPO_API_PATH             API path, e.g. "/api/v1/portal-access/{portalID}/"
+//	ESPO_API_KEY              API key; with ESPO_SECRET_KEY, HMAC authentication is used
+//	ESPO_SECRET_KEY           secret key for HMAC authentication
+//	ESPO_USERNAME             username for Basic authentication (with ESPO_PASSWORD)
+//	ESPO_PASSWORD             password for Basic authentication
+//	ESPO_TIMEOUT              per-attempt timeout, e.g. "30s"
+//	ESPO_RETRY_MAX_ATTEMPTS   total attempts for transient failures
+//	ESPO_RETRY_DELAY          pause between attempts, e.g. "500ms"
+//	ESPO_RATE_LIMIT           maximum requests per second
+//	ESPO_PROXY_URL            HTTP(S) or SOCKS5 proxy URL
+//	ESPO_MAX_BODY_SIZE        maximum request body size in bytes
+//
+// All problems are reported together in the returned error. The settings are
+// applied through NewClientFromConfig, so they behave as in a configuration file.
+func NewClientFromEnv() (*Client, error) {
+	var errs []error
+	invalid := func(name, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: "+format, append([]any{name}, args...)...))
+	}
+
+	baseURL := os.Getenv(EnvURL)
+	if baseURL == "" {
+		invalid(EnvURL, "is required")
+	} else if u, err := url.Parse(baseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		invalid(EnvURL, "%q is not an absolute URL", baseURL)
+	}
+
+	apiKey, secretKey := os.Getenv(EnvAPIKey), os.Getenv(EnvSecretKey)
+	username, password := os.Getenv(EnvUsername), os.Getenv(EnvPassword)
+	switch {
+	case secretKey != "" && apiKey == "":
+		invalid(EnvSecretKey, "requires %s", EnvAPIKey)
+	case apiKey != "" && username != "":
+		invalid(EnvUsername, "cannot be combined with %s", EnvAPIKey)
+	case username != "" && password == "":
+		invalid(EnvPassword, "is required with %s", EnvUsername)
+	case password != "" && username == "":
+		invalid(EnvUsername, "is required with %s", EnvPassword)
+	}
+
+	duration := func(name string) time.Duration {
+		v := os.Getenv(name)
+		if v == "" {
+			return 0
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			invalid(name, "%q is not a valid duration (e.g. \"30s\")", v)
+			return 0
+		}
+		return d
+	}
+	number := func(name string) int64 {
+		v := os.Getenv(name)
+		if v == "" {
+			return 0
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			invalid(name, "%q is not a non-negative integer", v)
+			return 0
+		}
+		return n
+	}
+	timeout := duration(EnvTimeout)
+	retryDelay := duration(EnvRetryDelay)
+	retryAttempts := number(EnvRetryMaxAttempts)
+	maxBodySize := number(EnvMaxBodySize)
+
+	var rateLimit float64
+	if v := os.Getenv(EnvRateLimit); v != "" {
+		var err error
+		if rateLimit, err = strconv.ParseFloat(v, 64); err != nil || rateLimit <= 0 {
+			invalid(EnvRateLimit, "%q is not a positive number", v)
+		}
+	}
+
+	proxyURL := os.Getenv(EnvProxyURL)
+	if u, err := url.Parse(proxyURL); proxyURL != "" && (err != nil || u.Scheme == "" || u.Host == "") {
+		invalid(EnvProxyURL, "%q is not an absolute URL", proxyURL)
+	}
+
+	if len(errs) > 0 {
+		return nil, &EspoError{Message: "invalid environment configuration", Cause: errors.Join(errs...)}
+	}
+
+	cfg := &Config{
+		URL:         baseURL,
+		APIPath:     os.Getenv(EnvAPIPath),
+		Auth:        AuthConfig{APIKey: apiKey, SecretKey: secretKey, Username: username, Password: password},
+		Timeout:     Duration(timeout),
+		MaxBodySize: maxBodySize,
+	}
+	if retryAttempts > 1 {
+		cfg.Retry = &RetryConfig{MaxAttempts: int(retryAttempts), Delay: Duration(retryDelay)}
+	}
+	if rateLimit > 0 {
+		cfg.RateLimit = &RateLimitConfig{RequestsPerSecond: rateLimit}
+	}
+	if proxyURL != "" {
+		cfg.Proxy = &ProxyConfig{URL: proxyURL}
+	}
+	return NewClientFromConfig(cfg)
+}
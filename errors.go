@@ -0,0 +1,112 @@
+package espoclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// errorBody is the JSON body EspoCRM sends with some error responses.
+type errorBody struct {
+	Message            string `json:"message"`
+	MessageTranslation *struct {
+		Label string `json:"label"`
+		Scope string `json:"scope"`
+		Data  struct {
+			Field string `json:"field"`
+			Type  string `json:"type"`
+		} `json:"data"`
+	} `json:"messageTranslation"`
+}
+
+// newResponseError wraps a non-2xx response, taking the reason from the
+// X-Status-Reason header or, failing that, from a JSON error body.
+func newResponseError(resp *Response) *ResponseError {
+	respErr := &ResponseError{Response: resp, ErrorMessage: resp.Headers.Get("X-Status-Reason")}
+	if respErr.ErrorMessage != "" {
+		return respErr
+	}
+	var body errorBody
+	if json.Unmarshal(resp.Body, &body) != nil {
+		return respErr
+	}
+	switch t := body.MessageTranslation; {
+	case t != nil && t.Label == "validationFailure" && t.Data.Field != "":
+		respErr.ErrorMessage = fmt.Sprintf("validation failed: %s: %s", t.Data.Field, t.Data.Type)
+	case body.Message != "":
+		respErr.ErrorMessage = body.Message
+	case body.MessageTranslation != nil && body.MessageTranslation.Label != "":
+		respErr.ErrorMessage = body.MessageTranslation.Label
+	}
+	return respErr
+}
+
+// ValidationError describes a record rejected by the server's validation (400 with
+// the validationFailure message). Get it from an error returned by the client with
+// errors.As:
+//
+//	var verr *espoclient.ValidationError
+//	if errors.As(err, &verr) {
+//		for field, reason := range verr.Fields { ... }
+//	}
+type ValidationError struct {
+	// Fields maps each invalid field to the failed validation, e.g. "required",
+	// "maxLength" or "valid".
+	Fields map[string]string
+	// Response is the error response; nil for a failure detected client-side,
+	// e.g. by FieldValidator.
+	Response *ResponseError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field, reason := range e.Fields {
+		fields = append(fields, field+": "+reason)
+	}
+	sort.Strings(fields)
+	return fmt.Sprintf("espoclient: validation failed (%s)", strings.Join(fields, ", "))
+}
+
+// Unwrap returns the error response, or ErrValidation for a client-side failure.
+func (e *ValidationError) Unwrap() error {
+	if e.Response == nil {
+		return ErrValidation
+	}
+	return e.Response
+}
+
+// As lets errors.As find a *ValidationError in validation failure responses and a
+// *StaleRecordError in responses to stale updates.
+func (e *ResponseError) As(target any) bool {
+	switch p := target.(type) {
+	case **ValidationError:
+		if verr := e.validationError(); verr != nil {
+			*p = verr
+			return true
+		}
+	case **StaleRecordError:
+		if serr := e.staleRecordError(); serr != nil {
+			*p = serr
+			return true
+		}
+	}
+	return false
+}
+
+// validationError parses the response body of a validation failure.
+func (e *ResponseError) validationError() *ValidationError {
+	if e.Response.StatusCode != http.StatusBadRequest {
+		return nil
+	}
+	var body errorBody
+	if json.Unmarshal(e.Response.Body, &body) != nil || body.MessageTranslation == nil {
+		return nil
+	}
+	t := body.MessageTranslation
+	if t.Label != "validationFailure" || t.Data.Field == "" {
+		return nil
+	}
+	return &ValidationError{Fields: map[string]string{t.Data.Field: t.Data.Type}, Response: e}
+}
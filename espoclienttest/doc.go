@@ -0,0 +1,24 @@
+// Package espoclienttest provides helpers for testing code that uses espoclient.
+//
+// Server is an in-memory fake EspoCRM server for tests without a live CRM:
+//
+//	srv := espoclienttest.NewServer().AddAPIKey("test-key")
+//	defer srv.Close()
+//	client, _ := srv.Client(espoclient.WithAPIKey("test-key"))
+//	lead, _ := client.CreateEntity(ctx, "Lead", map[string]any{"lastName": "Doe"})
+//
+// The server stores records of any entity type and supports create, read, update
+// (PUT and PATCH), delete and list requests, with where filters, text filters
+// (matching the start of any text attribute, "*" being a wildcard), ordering,
+// paging and select; primary and bool filters are rejected with 400. Equality on
+// emailAddresses.lower and phoneNumbers.numeric matches the primary and secondary
+// addresses and numbers of emailAddressData and phoneNumberData. Records are
+// listed in creation order unless ordered otherwise. Records seeded with a
+// versionNumber attribute get optimistic concurrency control as in EspoCRM: the
+// number is incremented on every update, and an update sending another one is
+// rejected with 409. Other endpoints respond with 404.
+//
+// Recorder records the traffic of a client against a real instance to golden files
+// and replays it in later test runs. FaultTransport injects latency, timeouts,
+// connection resets and error responses in front of either one.
+package espoclienttest
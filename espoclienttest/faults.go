@@ -0,0 +1,187 @@
+package espoclienttest
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// defaultHang is how long a simulated timeout blocks when the request has no deadline.
+const defaultHang = time.Minute
+
+// Faults configures what a FaultTransport injects. Rates are probabilities in [0, 1]
+// evaluated independently for every request, in the order timeout, reset, error
+// status, malformed body.
+type Faults struct {
+	Latency       time.Duration // added before every request
+	LatencyJitter time.Duration // random extra latency in [0, LatencyJitter)
+
+	TimeoutRate float64       // requests that hang until their context ends or Hang passes
+	Hang        time.Duration // defaultHang if zero
+
+	ResetRate float64 // requests failing with a connection reset
+
+	ErrorStatusRate float64 // requests answered with ErrorStatus without reaching the server
+	ErrorStatus     int     // http.StatusServiceUnavailable if zero
+
+	MalformedRate float64 // responses whose body is truncated, yielding invalid JSON
+
+	Seed uint64 // seed of the random source, for reproducible runs
+}
+
+// FaultCounts reports how many faults a FaultTransport injected.
+type FaultCounts struct {
+	Requests    int64
+	Timeouts    int64
+	Resets      int64
+	ErrorStatus int64
+	Malformed   int64
+}
+
+// FaultTransport is an http.RoundTripper that injects latency, timeouts, connection
+// resets, error statuses and malformed responses in front of another transport
+// (a real server or a fake one), for soak-testing retries and error handling:
+//
+//	transport := espoclienttest.NewFaultTransport(nil, espoclienttest.Faults{ResetRate: 0.2})
+//	client.SetHTTPClient(&http.Client{Transport: transport})
+type FaultTransport struct {
+	base   http.RoundTripper
+	faults Faults
+
+	mu   sync.Mutex
+	rand *rand.Rand
+
+	requests, timeouts, resets, errorStatus, malformed atomic.Int64
+}
+
+// NewFaultTransport wraps base (http.DefaultTransport if nil) with fault injection.
+func NewFaultTransport(base http.RoundTripper, faults Faults) *FaultTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &FaultTransport{
+		base:   base,
+		faults: faults,
+		rand:   rand.New(rand.NewPCG(faults.Seed, faults.Seed)),
+	}
+}
+
+// Counts returns the number of requests seen and faults injected so far.
+func (t *FaultTransport) Counts() FaultCounts {
+	return FaultCounts{
+		Requests:    t.requests.Load(),
+		Timeouts:    t.timeouts.Load(),
+		Resets:      t.resets.Load(),
+		ErrorStatus: t.errorStatus.Load(),
+		Malformed:   t.malformed.Load(),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	ctx := req.Context()
+	// A RoundTripper must close the request body, also when the request is not sent.
+	forwarded := false
+	defer func() {
+		if !forwarded && req.Body != nil {
+			req.Body.Close()
+		}
+	}()
+
+	if delay := t.latency(); delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.roll(t.faults.TimeoutRate) {
+		t.timeouts.Add(1)
+		hang := t.faults.Hang
+		if hang <= 0 {
+			hang = defaultHang
+		}
+		select {
+		case <-time.After(hang):
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.roll(t.faults.ResetRate) {
+		t.resets.Add(1)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	}
+
+	if t.roll(t.faults.ErrorStatusRate) {
+		t.errorStatus.Add(1)
+		status := t.faults.ErrorStatus
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+		return &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{"X-Status-Reason": {"Injected fault"}},
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	}
+
+	forwarded = true
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !t.roll(t.faults.MalformedRate) {
+		return resp, err
+	}
+
+	t.malformed.Add(1)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = append(body[:len(body)/2:len(body)/2], "\x00}"...)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+func (t *FaultTransport) latency() time.Duration {
+	delay := t.faults.Latency
+	if t.faults.LatencyJitter > 0 {
+		t.mu.Lock()
+		delay += time.Duration(t.rand.Int64N(int64(t.faults.LatencyJitter)))
+		t.mu.Unlock()
+	}
+	return delay
+}
+
+// roll returns true with probability rate.
+func (t *FaultTransport) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rand.Float64() < rate
+}
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout (injected)" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
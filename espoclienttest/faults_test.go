@@ -0,0 +1,54 @@
+package espoclienttest_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egorsmkv/go-espo-api-client/espoclienttest"
+)
+
+type trackedBody struct {
+	*strings.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+// Requests answered by an injected fault never reach the base transport, so the
+// fault transport itself must close their bodies.
+func TestFaultTransportClosesRequestBody(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		faults espoclienttest.Faults
+	}{
+		{"latency cancelled", cancelled, espoclienttest.Faults{Latency: time.Minute}},
+		{"timeout", cancelled, espoclienttest.Faults{TimeoutRate: 1}},
+		{"reset", context.Background(), espoclienttest.Faults{ResetRate: 1}},
+		{"error status", context.Background(), espoclienttest.Faults{ErrorStatusRate: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &trackedBody{Reader: strings.NewReader(`{"name":"x"}`)}
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodPost, "http://espo.test/api/v1/Lead", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			transport := espoclienttest.NewFaultTransport(nil, tt.faults)
+			if resp, err := transport.RoundTrip(req); err == nil {
+				resp.Body.Close()
+			}
+			if !body.closed {
+				t.Error("request body was not closed")
+			}
+		})
+	}
+}
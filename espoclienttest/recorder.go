@@ -0,0 +1,340 @@
+package espoclienttest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"unicode/utf8"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the golden file and never sends them.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and records them; Save writes the golden file.
+	ModeRecord
+	// ModeAuto replays if the golden file exists and records otherwise, so deleting
+	// the file refreshes the recording on the next run against a live instance.
+	ModeAuto
+)
+
+// redactedHeaders are replaced in recordings, so golden files hold no credentials.
+var redactedHeaders = []string{
+	"Authorization",
+	"Espo-Authorization",
+	"X-Api-Key",
+	"X-Hmac-Authorization",
+	"X-Auth-Token",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// redactedFields are JSON attributes replaced in recorded bodies, at any depth:
+// passwords sent when creating users, the keys generated for API users and the
+// auth token returned by App/user.
+var redactedFields = map[string]bool{
+	"password":        true,
+	"passwordConfirm": true,
+	"smtpPassword":    true,
+	"apiKey":          true,
+	"secretKey":       true,
+	"token":           true,
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded part of a request. The host is not recorded, so
+// recordings replay against any base URL.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URI     string      `json:"uri"` // path and query
+	Headers http.Header `json:"headers,omitempty"`
+	Body    Body        `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded part of a response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded body. It is stored as text, or base64-encoded if it is not UTF-8.
+type Body []byte
+
+// MarshalJSON implements json.Marshaler.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = Body(text)
+		return nil
+	}
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	*b = decoded
+	return err
+}
+
+// Recorder is an http.RoundTripper that records requests and responses to a golden
+// file and replays them in later runs, for contract tests against real EspoCRM
+// versions that run offline:
+//
+//	rec, err := espoclienttest.NewRecorder("testdata/leads.json", espoclienttest.ModeAuto)
+//	...
+//	defer rec.Save()
+//	client, err := espoclient.New(url, espoclient.WithAPIKey(key), rec.Option())
+//
+// Credential headers are redacted in recordings, and so are passwords, API keys
+// and auth tokens in JSON bodies; Scrub can remove other secrets.
+// Requests are matched on method, path, query and body, each recorded interaction
+// being used once, in order.
+type Recorder struct {
+	// Transport sends requests while recording; http.DefaultTransport if nil.
+	Transport http.RoundTripper
+	// Scrub, if set, is called with every interaction before it is recorded, e.g.
+	// to replace personal data in bodies. When replaying, it is called with the
+	// incoming request alone (Response is zero), so that it matches the scrubbed
+	// recording.
+	Scrub func(*Interaction)
+
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewRecorder returns a recorder for the golden file at path. In replay mode the
+// file is read immediately.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("espoclienttest: invalid recording %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	}
+	return r, nil
+}
+
+// Recording reports whether the recorder sends and records requests rather than
+// replaying them.
+func (r *Recorder) Recording() bool {
+	return r.mode == ModeRecord
+}
+
+// Option sends a client's requests through the recorder.
+func (r *Recorder) Option() espoclient.Option {
+	return espoclient.WithHTTPClient(&http.Client{Transport: r})
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := RecordedRequest{
+		Method:  req.Method,
+		URI:     req.URL.RequestURI(),
+		Headers: redact(req.Header),
+		// The redacted body is also what replay matches against.
+		Body: redactBody(body),
+	}
+	if r.mode == ModeReplay {
+		if r.Scrub != nil {
+			incoming := &Interaction{Request: recorded}
+			r.Scrub(incoming)
+			recorded = incoming.Request
+		}
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		rr := interaction.Request
+		if r.used[i] || rr.Method != recorded.Method || rr.URI != recorded.URI || !bytes.Equal(rr.Body, recorded.Body) {
+			continue
+		}
+		r.used[i] = true
+		resp := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        resp.Headers.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("espoclienttest: no recorded interaction for %s %s in %s", recorded.Method, recorded.URI, r.path)
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    redact(resp.Header),
+			Body:       redactBody(body),
+		},
+	}
+	if r.Scrub != nil {
+		r.Scrub(interaction)
+	}
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the golden file, creating its directory.
+// It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// Unused returns an error listing the recorded interactions that were not replayed,
+// which usually means the code under test no longer sends them.
+func (r *Recorder) Unused() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var errs []error
+	for i, used := range r.used {
+		if !used {
+			rr := r.interactions[i].Request
+			errs = append(errs, fmt.Errorf("espoclienttest: recorded interaction %s %s was not replayed", rr.Method, rr.URI))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// redact returns a copy of h with credentials replaced.
+func redact(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := h[name]; ok {
+			h[name] = []string{"[REDACTED]"}
+		}
+	}
+	return h
+}
+
+// redactBody returns body with the values of redactedFields replaced, if it is
+// JSON. Other bodies, and JSON bodies without such fields, are returned as is.
+func redactBody(body []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if dec.Decode(&v) != nil || !redactValue(v) {
+		return body
+	}
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// redactValue replaces the values of redactedFields in v and reports whether it
+// replaced any.
+func redactValue(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, elem := range v {
+			if redactedFields[k] {
+				if elem != nil && elem != "" {
+					v[k] = "[REDACTED]"
+					changed = true
+				}
+				continue
+			}
+			changed = redactValue(elem) || changed
+		}
+	case []any:
+		for _, elem := range v {
+			changed = redactValue(elem) || changed
+		}
+	}
+	return changed
+}
@@ -0,0 +1,110 @@
+package espoclienttest_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+	"github.com/egorsmkv/go-espo-api-client/espoclienttest"
+)
+
+func TestRecorderRedactsBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/App/user":
+			w.Write([]byte(`{"user":{"id":"u1","userName":"admin"},"token":"secret-token"}`))
+		default:
+			w.Write([]byte(`{"id":"u2","userName":"bot","apiKey":"secret-api-key","secretKey":"secret-hmac-key"}`))
+		}
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "recording.json")
+
+	run := func(rec *espoclienttest.Recorder) {
+		t.Helper()
+		client, err := espoclient.New(srv.URL, espoclient.WithAPIKey("key"), rec.Option())
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := context.Background()
+		if _, err := client.GetAppUser(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.CreateUser(ctx, espoclient.NewUser{UserName: "bot", Password: "secret-password"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec, err := espoclienttest.NewRecorder(path, espoclienttest.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(rec)
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-") {
+		t.Errorf("recording contains secrets:\n%s", data)
+	}
+
+	// Requests with redacted attributes still match their recordings.
+	rec, err = espoclienttest.NewRecorder(path, espoclienttest.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(rec)
+	if err := rec.Unused(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecorderReplaysScrubbedRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"l1"}`))
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "recording.json")
+	scrub := func(i *espoclienttest.Interaction) {
+		i.Request.Body = bytes.ReplaceAll(i.Request.Body, []byte("jane@example.com"), []byte("person@example.com"))
+	}
+
+	for _, mode := range []espoclienttest.Mode{espoclienttest.ModeRecord, espoclienttest.ModeReplay} {
+		rec, err := espoclienttest.NewRecorder(path, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec.Scrub = scrub
+		client, err := espoclient.New(srv.URL, espoclient.WithAPIKey("key"), rec.Option())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.CreateEntity(context.Background(), "Lead", map[string]any{"emailAddress": "jane@example.com"}); err != nil {
+			t.Fatalf("mode %v: %v", mode, err)
+		}
+		if err := rec.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "jane@") {
+		t.Errorf("recording contains personal data:\n%s", data)
+	}
+}
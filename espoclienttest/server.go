@@ -0,0 +1,345 @@
+package espoclienttest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+const apiPath = "/api/v1/"
+
+// Server is a fake EspoCRM instance listening on a local port. It is safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	records map[string]map[string]map[string]any // entity type -> ID -> record
+	order   map[string][]string                  // entity type -> IDs in creation order
+	nextID  int
+
+	apiKeys map[string]string // API key -> secret key, empty for plain API keys
+	users   map[string]string // username -> password
+}
+
+// NewServer starts a server with no records. Until credentials are added with
+// AddAPIKey, AddHMACKey or AddUser, requests are not authenticated. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		records: map[string]map[string]map[string]any{},
+		order:   map[string][]string{},
+		apiKeys: map[string]string{},
+		users:   map[string]string{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddAPIKey accepts requests authenticated with the API key.
+func (s *Server) AddAPIKey(apiKey string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKeys[apiKey] = ""
+	return s
+}
+
+// AddHMACKey accepts requests signed with the API key and secret key.
+func (s *Server) AddHMACKey(apiKey, secretKey string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKeys[apiKey] = secretKey
+	return s
+}
+
+// AddUser accepts requests authenticated with the username and password.
+func (s *Server) AddUser(username, password string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[username] = password
+	return s
+}
+
+// Client returns a client for the server, configured by opts.
+func (s *Server) Client(opts ...espoclient.Option) (*espoclient.Client, error) {
+	return espoclient.New(s.URL, opts...)
+}
+
+// Seed stores records of the entity type as if they were created through the API
+// and returns their IDs. Records with an "id" keep it.
+func (s *Server) Seed(entity string, records ...map[string]any) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = s.create(entity, record)["id"].(string)
+	}
+	return ids
+}
+
+// Record returns a copy of a stored record.
+func (s *Server) Record(entity, id string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[entity][id]
+	return maps.Clone(record), ok
+}
+
+// Records returns copies of the stored records of the entity type in creation order.
+func (s *Server) Records(entity string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]map[string]any, 0, len(s.order[entity]))
+	for _, id := range s.order[entity] {
+		records = append(records, maps.Clone(s.records[entity][id]))
+	}
+	return records
+}
+
+// Reset deletes all records.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.records)
+	clear(s.order)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path, ok := strings.CutPrefix(r.URL.Path, apiPath)
+	if !ok {
+		writeError(w, http.StatusNotFound, "")
+		return
+	}
+	if !s.authenticate(r) {
+		writeError(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if path == "App/user" {
+		writeJSON(w, map[string]any{
+			"user":        map[string]any{"id": "1", "userName": "admin", "type": "admin", "isAdmin": true},
+			"acl":         map[string]any{"table": map[string]any{}},
+			"preferences": map[string]any{},
+			"settings":    map[string]any{},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.list(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		var record map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		writeJSON(w, s.create(parts[0], record))
+	case len(parts) == 2:
+		s.serveRecord(w, r, parts[0], parts[1])
+	default:
+		writeError(w, http.StatusNotFound, "")
+	}
+}
+
+func (s *Server) serveRecord(w http.ResponseWriter, r *http.Request, entity, id string) {
+	record, ok := s.records[entity][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, record)
+	case http.MethodPut, http.MethodPatch:
+		var changes map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		delete(changes, "id")
+		if version, ok := number(record["versionNumber"]); ok {
+			if sent, ok := changes["versionNumber"]; ok && sent != version {
+				w.Header().Set("X-Status-Reason", "modified")
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(record)
+				return
+			}
+			changes["versionNumber"] = version + 1
+		}
+		maps.Copy(record, changes)
+		record["modifiedAt"] = now()
+		writeJSON(w, record)
+	case http.MethodDelete:
+		delete(s.records[entity], id)
+		s.order[entity] = slices.DeleteFunc(s.order[entity], func(v string) bool { return v == id })
+		writeJSON(w, true)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "")
+	}
+}
+
+// create stores a copy of record with an ID and timestamps and returns it.
+func (s *Server) create(entity string, record map[string]any) map[string]any {
+	record = maps.Clone(record)
+	if record == nil {
+		record = map[string]any{}
+	}
+	id, _ := record["id"].(string)
+	if id == "" {
+		s.nextID++
+		id = fmt.Sprintf("%017x", s.nextID)
+		record["id"] = id
+	}
+	record["createdAt"] = now()
+	record["modifiedAt"] = record["createdAt"]
+	if s.records[entity] == nil {
+		s.records[entity] = map[string]map[string]any{}
+	}
+	if _, exists := s.records[entity][id]; !exists {
+		s.order[entity] = append(s.order[entity], id)
+	}
+	s.records[entity][id] = record
+	return record
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, entity string) {
+	query := r.URL.Query()
+	where, err := parseWhere(query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if query.Get("primaryFilter") != "" || query.Has("boolFilterList[]") {
+		writeError(w, http.StatusBadRequest, "primary and bool filters are not supported")
+		return
+	}
+	if text := query.Get("textFilter"); text != "" {
+		where = append(where, whereItem{Type: "textFilter", Value: text})
+	}
+
+	var matched []map[string]any
+	for _, id := range s.order[entity] {
+		record := s.records[entity][id]
+		ok, err := matchAll(record, where)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if ok {
+			matched = append(matched, record)
+		}
+	}
+
+	if orderBy := query.Get("orderBy"); orderBy != "" {
+		desc := strings.EqualFold(query.Get("order"), "desc")
+		slices.SortStableFunc(matched, func(a, b map[string]any) int {
+			c := compare(a[orderBy], b[orderBy])
+			if desc {
+				return -c
+			}
+			return c
+		})
+	}
+
+	total := len(matched)
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	matched = matched[min(max(offset, 0), total):]
+	if maxSize, err := strconv.Atoi(query.Get("maxSize")); err == nil && maxSize >= 0 && maxSize < len(matched) {
+		matched = matched[:maxSize]
+	}
+
+	list := make([]map[string]any, len(matched))
+	selected := strings.Split(query.Get("select"), ",")
+	for i, record := range matched {
+		if query.Get("select") == "" {
+			list[i] = record
+			continue
+		}
+		list[i] = map[string]any{"id": record["id"]}
+		for _, attr := range selected {
+			if v, ok := record[attr]; ok {
+				list[i][attr] = v
+			}
+		}
+	}
+	writeJSON(w, map[string]any{"total": total, "list": list})
+}
+
+// authenticate checks the credentials of a request, if any are configured.
+func (s *Server) authenticate(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.apiKeys) == 0 && len(s.users) == 0 {
+		return true
+	}
+	if key := r.Header.Get("X-Api-Key"); key != "" {
+		secret, ok := s.apiKeys[key]
+		return ok && secret == ""
+	}
+	if auth := r.Header.Get("X-Hmac-Authorization"); auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(auth)
+		if err != nil {
+			return false
+		}
+		key, signature, _ := strings.Cut(string(decoded), ":")
+		secret, ok := s.apiKeys[key]
+		if !ok || secret == "" {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		// The signed path is relative to the API path, as escaped on the wire.
+		path := strings.TrimPrefix(r.URL.EscapedPath(), strings.TrimSuffix(apiPath, "/"))
+		mac.Write([]byte(r.Method + " " + path))
+		return hmac.Equal([]byte(signature), []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil))))
+	}
+	if username, password, ok := r.BasicAuth(); ok {
+		expected, known := s.users[username]
+		return known && expected == password
+	}
+	return false
+}
+
+// number converts a numeric attribute, as decoded from JSON or seeded, to float64.
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func now() string {
+	return espoclient.FormatDateTime(time.Now())
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, reason string) {
+	if reason != "" {
+		w.Header().Set("X-Status-Reason", reason)
+	}
+	w.WriteHeader(status)
+}
@@ -0,0 +1,239 @@
+package espoclienttest
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// whereItem is a condition decoded from the where[...] query parameters.
+type whereItem struct {
+	Type      string
+	Attribute string
+	Value     any // string, []any of strings or, for groups, []whereItem
+}
+
+// parseWhere decodes the where clause of a list request from the bracket notation
+// espoclient sends (where[0][type]=equals&where[0][attribute]=name&...).
+func parseWhere(query url.Values) ([]whereItem, error) {
+	tree := map[string]any{}
+	for key, values := range query {
+		rest, ok := strings.CutPrefix(key, "where[")
+		if !ok || len(values) == 0 {
+			continue
+		}
+		segments := strings.Split(strings.TrimSuffix(rest, "]"), "][")
+		node := tree
+		for _, segment := range segments[:len(segments)-1] {
+			child, ok := node[segment].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[segment] = child
+			}
+			node = child
+		}
+		node[segments[len(segments)-1]] = values[0]
+	}
+	return toItems(toList(tree))
+}
+
+// toList converts the nested maps of indexed entries into lists.
+func toList(node any) any {
+	m, ok := node.(map[string]any)
+	if !ok {
+		return node
+	}
+	indexes := make([]int, 0, len(m))
+	for key := range m {
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			converted := map[string]any{}
+			for k, v := range m {
+				converted[k] = toList(v)
+			}
+			return converted
+		}
+		indexes = append(indexes, i)
+	}
+	slices.Sort(indexes)
+	list := make([]any, len(indexes))
+	for j, i := range indexes {
+		list[j] = toList(m[strconv.Itoa(i)])
+	}
+	return list
+}
+
+func toItems(node any) ([]whereItem, error) {
+	list, _ := node.([]any)
+	items := make([]whereItem, 0, len(list))
+	for _, elem := range list {
+		m, ok := elem.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid where clause")
+		}
+		item := whereItem{Value: m["value"]}
+		item.Type, _ = m["type"].(string)
+		item.Attribute, _ = m["attribute"].(string)
+		switch item.Type {
+		case "or", "and", "not":
+			nested, err := toItems(item.Value)
+			if err != nil {
+				return nil, err
+			}
+			item.Value = nested
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func matchAll(record map[string]any, items []whereItem) (bool, error) {
+	for _, item := range items {
+		ok, err := match(record, item)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// match evaluates one condition against a record.
+func match(record map[string]any, item whereItem) (bool, error) {
+	value := record[item.Attribute]
+	want, _ := item.Value.(string)
+	if values, ok := addressValues(record, item.Attribute); ok && item.Type == "equals" {
+		return slices.Contains(values, want), nil
+	}
+	switch item.Type {
+	case "and":
+		return matchAll(record, item.Value.([]whereItem))
+	case "or":
+		for _, nested := range item.Value.([]whereItem) {
+			if ok, err := match(record, nested); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case "not":
+		ok, err := matchAll(record, item.Value.([]whereItem))
+		return !ok, err
+	case "equals":
+		return value != nil && compare(value, want) == 0, nil
+	case "notEquals":
+		return value == nil || compare(value, want) != 0, nil
+	case "in", "notIn":
+		list, _ := item.Value.([]any)
+		found := value != nil && slices.ContainsFunc(list, func(v any) bool { return compare(value, v) == 0 })
+		return found == (item.Type == "in"), nil
+	case "contains":
+		return value != nil && strings.Contains(fmt.Sprint(value), want), nil
+	case "notContains":
+		return value == nil || !strings.Contains(fmt.Sprint(value), want), nil
+	case "startsWith":
+		return value != nil && strings.HasPrefix(fmt.Sprint(value), want), nil
+	case "endsWith":
+		return value != nil && strings.HasSuffix(fmt.Sprint(value), want), nil
+	case "like":
+		pattern := strings.NewReplacer("%", "*", "_", "?").Replace(want)
+		ok, err := path.Match(pattern, fmt.Sprint(value))
+		return value != nil && ok, err
+	case "greaterThan":
+		return value != nil && compare(value, want) > 0, nil
+	case "greaterThanOrEquals":
+		return value != nil && compare(value, want) >= 0, nil
+	case "lessThan":
+		return value != nil && compare(value, want) < 0, nil
+	case "lessThanOrEquals":
+		return value != nil && compare(value, want) <= 0, nil
+	case "between":
+		bounds, _ := item.Value.([]any)
+		if len(bounds) != 2 {
+			return false, fmt.Errorf("between needs two values")
+		}
+		return value != nil && compare(value, bounds[0]) >= 0 && compare(value, bounds[1]) <= 0, nil
+	case "textFilter":
+		pattern := strings.ToLower(want) + "*"
+		for _, v := range record {
+			if text, ok := v.(string); ok {
+				if ok, _ := path.Match(pattern, strings.ToLower(text)); ok {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case "isNull":
+		return value == nil || value == "", nil
+	case "isNotNull":
+		return value != nil && value != "", nil
+	case "isTrue":
+		return value == true, nil
+	case "isFalse":
+		return value != true, nil
+	}
+	return false, fmt.Errorf("unsupported where type %q", item.Type)
+}
+
+// addressValues returns the values of the emailAddresses.lower and
+// phoneNumbers.numeric link attributes, which EspoCRM derives from the primary
+// and secondary email addresses and phone numbers of a record.
+func addressValues(record map[string]any, attribute string) ([]string, bool) {
+	var field, dataKey string
+	var normalize func(string) string
+	switch attribute {
+	case "emailAddresses.lower":
+		field, dataKey, normalize = "emailAddress", "emailAddress", strings.ToLower
+	case "phoneNumbers.numeric":
+		field, dataKey, normalize = "phoneNumber", "phoneNumber", func(s string) string {
+			return strings.Map(func(r rune) rune {
+				if r >= '0' && r <= '9' {
+					return r
+				}
+				return -1
+			}, s)
+		}
+	default:
+		return nil, false
+	}
+	var values []string
+	if s, ok := record[field].(string); ok && s != "" {
+		values = append(values, normalize(s))
+	}
+	data, _ := record[field+"Data"].([]any)
+	for _, elem := range data {
+		entry, _ := elem.(map[string]any)
+		if s, ok := entry[dataKey].(string); ok && s != "" {
+			values = append(values, normalize(s))
+		}
+	}
+	return values, true
+}
+
+// compare orders two attribute values, numerically if both are numbers and by
+// their text otherwise. Query values are always text, so both are compared as
+// they would print. nil sorts first.
+func compare(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	as, bs := fmt.Sprint(a), fmt.Sprint(b)
+	af, aErr := strconv.ParseFloat(as, 64)
+	bf, bErr := strconv.ParseFloat(bs, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(as, bs)
+}
@@ -0,0 +1,360 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Topics of the EspoCRM WebSocket.
+const (
+	// TopicNewNotification delivers the notifications of the user.
+	TopicNewNotification = "newNotification"
+)
+
+// RecordUpdateTopic is the topic of the updates of a record.
+func RecordUpdateTopic(entity, id string) string {
+	return "recordUpdate." + entity + "." + id
+}
+
+// StreamUpdateTopic is the topic of the notes posted to the stream of a record.
+func StreamUpdateTopic(entity, id string) string {
+	return "streamUpdate." + entity + "." + id
+}
+
+// Event is a message received on a subscribed topic.
+type Event struct {
+	Topic string
+	Data  json.RawMessage
+}
+
+// Decode decodes the event data into v. Data sent as a JSON-encoded string, as
+// EspoCRM does for some topics, is decoded from the string's content.
+func (e Event) Decode(v any) error {
+	var text string
+	if json.Unmarshal(e.Data, &text) == nil && json.Valid([]byte(text)) {
+		return json.Unmarshal([]byte(text), v)
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+// EventStreamOptions configures OpenEventStream.
+type EventStreamOptions struct {
+	// URL is the WebSocket URL; the webSocketUrl setting of the instance, or its
+	// site URL with the ws or wss scheme and the /wss path, if empty.
+	URL    string
+	Topics []string // topics subscribed initially
+	Buffer int      // capacity of the event channel; 64 if zero
+	// PingInterval is the time between pings, which detect dead connections; 30
+	// seconds if zero, none if negative.
+	PingInterval time.Duration
+	// MinReconnectDelay and MaxReconnectDelay bound the wait before reconnecting,
+	// which doubles after each failed attempt; 1 second and 1 minute if zero.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+	// OnError, if set, is called with the errors that drop a connection or fail a
+	// reconnection attempt. The stream keeps reconnecting.
+	OnError func(err error)
+}
+
+// EventStream receives real-time events from the WebSocket of EspoCRM, which must
+// be enabled on the instance (useWebSocket). It reconnects automatically, with
+// backoff, and subscribes to its topics again.
+//
+//	events, err := client.OpenEventStream(ctx, espoclient.EventStreamOptions{
+//		Topics: []string{espoclient.RecordUpdateTopic("Lead", id)},
+//	})
+//	...
+//	for event := range events.Events() { ... }
+type EventStream struct {
+	client *Client
+	token  *tokenAuth
+	url    *url.URL
+	userID string
+	opts   EventStreamOptions
+	events chan Event
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	topics map[string]bool
+	conn   *wsConn // nil while disconnected
+}
+
+// OpenEventStream connects to the WebSocket of the instance and subscribes to the
+// topics of opts. The WebSocket only accepts auth tokens, so the client must use
+// SetTokenAuth. The stream ends when ctx is cancelled or Close is called.
+func (c *Client) OpenEventStream(ctx context.Context, opts EventStreamOptions) (*EventStream, error) {
+	t := c.auth().token
+	if t == nil {
+		return nil, &EspoError{Message: "the WebSocket requires token authentication (SetTokenAuth)"}
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 64
+	}
+	if opts.PingInterval == 0 {
+		opts.PingInterval = 30 * time.Second
+	}
+	if opts.MinReconnectDelay <= 0 {
+		opts.MinReconnectDelay = time.Second
+	}
+	if opts.MaxReconnectDelay <= 0 {
+		opts.MaxReconnectDelay = time.Minute
+	}
+
+	user, err := c.GetAppUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wsURL, err := c.webSocketURL(ctx, opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	s := &EventStream{
+		client: c,
+		token:  t,
+		url:    wsURL,
+		userID: user.User.ID,
+		opts:   opts,
+		events: make(chan Event, opts.Buffer),
+		done:   make(chan struct{}),
+		topics: map[string]bool{},
+	}
+	for _, topic := range opts.Topics {
+		s.topics[topic] = true
+	}
+
+	conn, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	go s.run(ctx, conn)
+	return s, nil
+}
+
+// webSocketURL returns the WebSocket URL to use.
+func (c *Client) webSocketURL(ctx context.Context, configured string) (*url.URL, error) {
+	if configured == "" {
+		settings, err := c.GetSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		configured, _ = settings.Raw["webSocketUrl"].(string)
+		if configured == "" {
+			site := settings.SiteURL
+			if site == "" {
+				site = c.baseURL.String()
+			}
+			configured = strings.TrimSuffix(site, "/") + "/wss"
+		}
+	}
+	u, err := url.Parse(configured)
+	if err != nil {
+		return nil, &EspoError{Message: "invalid WebSocket URL", Cause: err}
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	return u, nil
+}
+
+// Events returns the channel of received events. It is closed when the stream ends.
+func (s *EventStream) Events() <-chan Event {
+	return s.events
+}
+
+// Subscribe adds topics to the stream.
+func (s *EventStream) Subscribe(topics ...string) error {
+	return s.setTopics(topics, true)
+}
+
+// Unsubscribe removes topics from the stream.
+func (s *EventStream) Unsubscribe(topics ...string) error {
+	return s.setTopics(topics, false)
+}
+
+func (s *EventStream) setTopics(topics []string, subscribe bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range topics {
+		if s.topics[topic] == subscribe {
+			continue
+		}
+		if subscribe {
+			s.topics[topic] = true
+		} else {
+			delete(s.topics, topic)
+		}
+		// If the connection is down, the topics are sent when it is restored.
+		if s.conn != nil {
+			if err := s.conn.writeText(wampTopicMessage(subscribe, topic)); err != nil {
+				return &EspoError{Message: "failed to update WebSocket subscriptions", Cause: err}
+			}
+		}
+	}
+	return nil
+}
+
+// Close ends the stream and closes its connection.
+func (s *EventStream) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// WAMP v1 message types used by EspoCRM.
+const (
+	wampSubscribe   = 5
+	wampUnsubscribe = 6
+	wampEvent       = 8
+)
+
+func wampTopicMessage(subscribe bool, topic string) []byte {
+	kind := wampUnsubscribe
+	if subscribe {
+		kind = wampSubscribe
+	}
+	msg, _ := json.Marshal([]any{kind, topic})
+	return msg
+}
+
+// connect opens a connection and subscribes to the topics.
+func (s *EventStream) connect(ctx context.Context) (*wsConn, error) {
+	token, err := s.client.authToken(ctx, s.token)
+	if err != nil {
+		return nil, err
+	}
+	u := *s.url
+	query := u.Query()
+	query.Set("authToken", token)
+	query.Set("userId", s.userID)
+	u.RawQuery = query.Encode()
+
+	// The connection outlives the client-wide request timeout.
+	hc := *s.client.httpClient
+	hc.Timeout = 0
+	conn, resp, err := dialWebSocket(ctx, &hc, &u, "wamp")
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			s.token.expire(token) // log in again on the next attempt
+		}
+		return nil, &EspoError{Message: "WebSocket connection failed", Cause: err}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for topic := range s.topics {
+		if err := conn.writeText(wampTopicMessage(true, topic)); err != nil {
+			conn.close()
+			return nil, &EspoError{Message: "failed to subscribe", Cause: err}
+		}
+	}
+	s.conn = conn
+	return conn, nil
+}
+
+// run delivers the events of conn and reconnects when it fails, until ctx ends.
+func (s *EventStream) run(ctx context.Context, conn *wsConn) {
+	defer close(s.done)
+	defer close(s.events)
+	clock := s.client.clock
+	delay := s.opts.MinReconnectDelay
+	for {
+		if conn != nil {
+			err := s.serve(ctx, conn)
+			s.mu.Lock()
+			s.conn = nil
+			s.mu.Unlock()
+			if ctx.Err() != nil {
+				return
+			}
+			s.report(&EspoError{Message: "WebSocket connection lost", Cause: err})
+			delay = s.opts.MinReconnectDelay
+		}
+		select {
+		case <-clock.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		var err error
+		if conn, err = s.connect(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.report(err)
+			delay = min(delay*2, s.opts.MaxReconnectDelay)
+		}
+	}
+}
+
+// serve reads the events of conn until it fails or ctx ends.
+func (s *EventStream) serve(ctx context.Context, conn *wsConn) error {
+	clock := s.client.clock
+	var lastRead atomic.Int64
+	lastRead.Store(clock.Now().UnixNano())
+	conn.onRead = func() { lastRead.Store(clock.Now().UnixNano()) }
+
+	// Close the connection when ctx ends, or when it stops answering pings.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		var tick <-chan time.Time
+		for {
+			if s.opts.PingInterval > 0 {
+				tick = clock.After(s.opts.PingInterval)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				conn.close()
+				return
+			case <-tick:
+				if clock.Now().Sub(time.Unix(0, lastRead.Load())) > 2*s.opts.PingInterval {
+					conn.close()
+					return
+				}
+				conn.ping()
+			}
+		}
+	}()
+
+	for {
+		msg, err := conn.readMessage()
+		if err != nil {
+			conn.rwc.Close()
+			return err
+		}
+
+		var parts []json.RawMessage
+		if json.Unmarshal(msg, &parts) != nil || len(parts) < 3 {
+			continue // welcome and other protocol messages
+		}
+		var kind int
+		var topic string
+		if json.Unmarshal(parts[0], &kind) != nil || kind != wampEvent || json.Unmarshal(parts[1], &topic) != nil {
+			continue
+		}
+		select {
+		case s.events <- Event{Topic: topic, Data: parts[2]}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// report passes an error to OnError.
+func (s *EventStream) report(err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
@@ -0,0 +1,159 @@
+package espoclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultNotFoundTTL is how long Exists remembers that a record does not exist.
+	defaultNotFoundTTL = 30 * time.Second
+	// maxNotFoundEntries bounds the not-found cache; expired entries are swept when it is reached.
+	maxNotFoundEntries = 10000
+)
+
+// HEAD support as learned by Exists.
+const (
+	headUnknown = iota
+	headSupported
+	headUnsupported
+)
+
+// existsCache holds the state of Exists: whether the server answers HEAD on record
+// endpoints and which records were recently found missing.
+type existsCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	head     int
+	notFound map[string]time.Time // entity/id -> expiry
+}
+
+func newExistsCache() *existsCache {
+	return &existsCache{ttl: defaultNotFoundTTL, notFound: map[string]time.Time{}}
+}
+
+// SetNotFoundCacheTTL sets how long Exists caches that a record does not exist.
+// A TTL of zero or less disables the cache.
+func (c *Client) SetNotFoundCacheTTL(ttl time.Duration) *Client {
+	c.exists.mu.Lock()
+	c.exists.ttl = ttl
+	c.exists.mu.Unlock()
+	return c
+}
+
+// Exists reports whether a record exists without fetching its body. It sends a
+// HEAD request and, where the server does not support HEAD, a list query
+// selecting only the ID. Missing records are cached briefly (see
+// SetNotFoundCacheTTL), which keeps high-volume dedup checks cheap.
+// Errors other than 404 (e.g. 403) are returned.
+func (c *Client) Exists(ctx context.Context, entity, id string) (bool, error) {
+	key := entity + "/" + id
+	if c.exists.cachedNotFound(key, c.clock.Now()) {
+		return false, nil
+	}
+
+	c.exists.mu.Lock()
+	head := c.exists.head
+	c.exists.mu.Unlock()
+
+	var found bool
+	var err error
+	if head == headUnsupported {
+		found, err = c.existsByList(ctx, entity, id)
+	} else {
+		found, err = c.existsByHead(ctx, entity, id, head)
+	}
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		c.exists.storeNotFound(key, c.clock.Now())
+	}
+	return found, nil
+}
+
+// existsByHead checks a record with HEAD, learning whether the server supports it.
+func (c *Client) existsByHead(ctx context.Context, entity, id string, head int) (bool, error) {
+	_, err := c.request(ctx, http.MethodHead, entity+"/"+url.PathEscape(id), nil, nil)
+	if err == nil {
+		c.exists.setHead(headSupported)
+		return true, nil
+	}
+
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		return false, err
+	}
+	switch respErr.Response.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		c.exists.setHead(headUnsupported)
+		return c.existsByList(ctx, entity, id)
+	case http.StatusNotFound:
+		if head == headSupported {
+			return false, nil
+		}
+		// A 404 may also mean that no route handles HEAD; confirm with a query.
+		found, err := c.existsByList(ctx, entity, id)
+		if err == nil && found {
+			c.exists.setHead(headUnsupported)
+		}
+		return found, err
+	}
+	return false, err
+}
+
+// existsByList checks a record with a one-row list query selecting only the ID.
+func (c *Client) existsByList(ctx context.Context, entity, id string) (bool, error) {
+	params := &SearchParams{
+		Where:  []WhereItem{{Type: "equals", Attribute: "id", Value: id}},
+		Select: []string{"id"},
+	}
+	page, err := c.listPage(ctx, entity, params.Values(), 0, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(page.List) > 0, nil
+}
+
+func (e *existsCache) setHead(state int) {
+	e.mu.Lock()
+	e.head = state
+	e.mu.Unlock()
+}
+
+func (e *existsCache) cachedNotFound(key string, now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	expiry, ok := e.notFound[key]
+	if !ok {
+		return false
+	}
+	if now.After(expiry) {
+		delete(e.notFound, key)
+		return false
+	}
+	return true
+}
+
+func (e *existsCache) storeNotFound(key string, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.ttl <= 0 {
+		return
+	}
+	if len(e.notFound) >= maxNotFoundEntries {
+		for k, expiry := range e.notFound {
+			if now.After(expiry) {
+				delete(e.notFound, k)
+			}
+		}
+		if len(e.notFound) >= maxNotFoundEntries {
+			return
+		}
+	}
+	e.notFound[key] = now.Add(e.ttl)
+}
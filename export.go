@@ -0,0 +1,131 @@
+package espoclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// defaultExportPollInterval is used by Export when PollInterval is not positive.
+const defaultExportPollInterval = 2 * time.Second
+
+// ExportFormat is the file format of an export.
+type ExportFormat string
+
+// Export formats supported by EspoCRM.
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportXLSX ExportFormat = "xlsx"
+)
+
+// ExportRequest describes the records and fields to export.
+type ExportRequest struct {
+	Format ExportFormat // ExportCSV if empty
+	// IDs selects records explicitly; otherwise the where clause, filters and
+	// order of Params apply (all records if nil).
+	IDs    []string
+	Params *SearchParams
+	// Fields lists the fields to export; the entity's default export fields if empty.
+	Fields []string
+	// Background runs the export as a job on the server, which is polled every
+	// PollInterval (2s if zero) until the file is ready.
+	Background   bool
+	PollInterval time.Duration
+}
+
+// Export exports records of the entity to a CSV or XLSX file and returns the file's
+// content, read from the server as the caller consumes it. The caller must close it.
+func (c *Client) Export(ctx context.Context, entity string, req ExportRequest) (io.ReadCloser, error) {
+	if err := req.Params.Validate(); err != nil {
+		return nil, err
+	}
+	format := req.Format
+	if format == "" {
+		format = ExportCSV
+	}
+	body := map[string]any{
+		"entityType": entity,
+		"format":     format,
+	}
+	if len(req.IDs) > 0 {
+		body["ids"] = req.IDs
+	} else {
+		where := []WhereItem{}
+		if req.Params != nil && req.Params.Where != nil {
+			where = req.Params.Where
+		}
+		body["where"] = where
+		if p := req.Params; p != nil && (p.OrderBy != "" || p.hasFilters()) {
+			searchParams := map[string]any{}
+			if p.OrderBy != "" {
+				searchParams["orderBy"] = p.OrderBy
+				searchParams["order"] = p.Order
+			}
+			p.addFilters(searchParams)
+			body["searchParams"] = searchParams
+		}
+	}
+	if len(req.Fields) > 0 {
+		body["fieldList"] = req.Fields
+	}
+	if req.Background {
+		body["idle"] = true
+	}
+
+	resp, err := c.request(ctx, MethodPost, "Export", body, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		AttachmentID string `json:"id"`
+		ExportID     string `json:"exportId"`
+	}
+	if err := resp.GetParsedBody(&result); err != nil {
+		return nil, &EspoError{Message: "failed to parse export result", Cause: err}
+	}
+
+	attachmentID := result.AttachmentID
+	if attachmentID == "" && result.ExportID != "" {
+		interval := req.PollInterval
+		if interval <= 0 {
+			interval = defaultExportPollInterval
+		}
+		if attachmentID, err = c.waitForExport(ctx, result.ExportID, interval); err != nil {
+			return nil, err
+		}
+	}
+	if attachmentID == "" {
+		return nil, &EspoError{Message: "export result contains no file"}
+	}
+
+	file, err := c.RequestStream(ctx, MethodGet, "Attachment/file/"+url.PathEscape(attachmentID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return file.Body, nil
+}
+
+// waitForExport polls a background export until it finishes and returns the ID
+// of the produced attachment.
+func (c *Client) waitForExport(ctx context.Context, exportID string, interval time.Duration) (string, error) {
+	for {
+		status, err := c.getObject(ctx, "Export/"+url.PathEscape(exportID)+"/status", nil)
+		if err != nil {
+			return "", err
+		}
+		switch s, _ := status["status"].(string); s {
+		case "Success":
+			id, _ := status["attachmentId"].(string)
+			return id, nil
+		case "Failed":
+			return "", &EspoError{Message: fmt.Sprintf("export %s failed", exportID)}
+		}
+		select {
+		case <-c.clock.After(interval):
+		case <-ctx.Done():
+			return "", &EspoError{Message: "export wait aborted", Cause: ctx.Err()}
+		}
+	}
+}
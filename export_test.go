@@ -0,0 +1,45 @@
+package espoclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestExportForwardsFilters(t *testing.T) {
+	var searchParams json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/Export" {
+			var body struct{ SearchParams json.RawMessage }
+			json.NewDecoder(r.Body).Decode(&body)
+			searchParams = body.SearchParams
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"f1"}`))
+			return
+		}
+		w.Write([]byte("id,name\n"))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := &espoclient.SearchParams{OrderBy: "name", TextFilter: "acme*", PrimaryFilter: "actual"}
+	file, err := client.Export(context.Background(), "Lead", espoclient.ExportRequest{Params: params})
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(io.Discard, file)
+	file.Close()
+
+	want := `{"order":"","orderBy":"name","primaryFilter":"actual","textFilter":"acme*"}`
+	if string(searchParams) != want {
+		t.Errorf("searchParams = %s, want %s", searchParams, want)
+	}
+}
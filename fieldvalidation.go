@@ -0,0 +1,216 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+)
+
+// FieldValidator checks enum, multi-enum, number and currency attributes of record
+// payloads against the metadata before they are sent, so mistakes surface as a
+// ValidationError naming every offending field instead of the server's 400 for the
+// first one. It also rounds amounts the way EspoCRM stores them. It holds a snapshot
+// of metadata and settings and is safe for concurrent use.
+type FieldValidator struct {
+	metadata map[string]any
+	settings *Settings
+}
+
+// NewFieldValidator builds a FieldValidator from already fetched metadata and
+// settings. settings may be nil, which disables the checks of currency codes and
+// the rounding of amounts.
+func NewFieldValidator(metadata map[string]any, settings *Settings) *FieldValidator {
+	return &FieldValidator{metadata: metadata, settings: settings}
+}
+
+// FieldValidator returns a validator over the cached metadata and the current
+// settings. Keep it around rather than calling this per record.
+func (c *Client) FieldValidator(ctx context.Context) (*FieldValidator, error) {
+	metadata, err := c.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := c.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewFieldValidator(metadata, settings), nil
+}
+
+// Validate checks the attributes of record that belong to enum, multiEnum,
+// checklist, int, float and currency fields of the entity. Other attributes, and
+// null values, are not checked. It returns a *ValidationError, matching
+// ErrValidation, with the failed check of each invalid field: "valid", "min" or "max".
+func (v *FieldValidator) Validate(entity string, record map[string]any) error {
+	failures := map[string]string{}
+	for field, value := range record {
+		if value == nil {
+			continue
+		}
+		if reason := v.check(entity, field, value); reason != "" {
+			failures[field] = reason
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: failures}
+}
+
+// Hook returns a RecordHook applying Validate to create and update payloads.
+func (v *FieldValidator) Hook() RecordHook {
+	return func(_ context.Context, _ Operation, entity string, record map[string]any) error {
+		return v.Validate(entity, record)
+	}
+}
+
+// Currency returns the attributes of a currency field for an amount: the amount
+// rounded to the currency decimal places of the settings and the currency code
+// ("{field}Currency"), the default currency if empty.
+//
+//	data, err := validator.Currency("Opportunity", "amount", 1234.567, "EUR")
+//	// {"amount": 1234.57, "amountCurrency": "EUR"}
+func (v *FieldValidator) Currency(entity, field string, amount float64, currency string) (map[string]any, error) {
+	if currency == "" && v.settings != nil {
+		currency = v.settings.DefaultCurrency
+	}
+	data := map[string]any{field: v.Round(entity, field, amount), field + "Currency": currency}
+	if err := v.Validate(entity, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Round rounds a value of a float or currency field to the decimal places EspoCRM
+// stores: those of the field's definition, or for currency fields the currency
+// decimal places of the settings. Other values are returned unchanged.
+func (v *FieldValidator) Round(entity, field string, value float64) float64 {
+	places := -1
+	if n, ok := toFloat(v.fieldParam(entity, field, "decimalPlaces")); ok {
+		places = int(n)
+	} else if v.fieldType(entity, field) == "currency" && v.settings != nil && v.settings.CurrencyDecimalPlaces != nil {
+		places = *v.settings.CurrencyDecimalPlaces
+	}
+	if places < 0 {
+		return value
+	}
+	scale := math.Pow10(places)
+	return math.Round(value*scale) / scale
+}
+
+// check returns the failed check of one attribute, or "" if it is valid.
+func (v *FieldValidator) check(entity, attribute string, value any) string {
+	switch v.fieldType(entity, attribute) {
+	case "enum":
+		if s, ok := value.(string); !ok || !v.allowsOption(entity, attribute, s) {
+			return "valid"
+		}
+	case "multiEnum", "checklist", "array":
+		items, ok := toStrings(value)
+		if !ok {
+			return "valid"
+		}
+		for _, item := range items {
+			if !v.allowsOption(entity, attribute, item) {
+				return "valid"
+			}
+		}
+	case "int":
+		n, ok := toFloat(value)
+		if !ok || n != math.Trunc(n) {
+			return "valid"
+		}
+		return v.checkRange(entity, attribute, n)
+	case "float", "currency":
+		n, ok := toFloat(value)
+		if !ok {
+			return "valid"
+		}
+		return v.checkRange(entity, attribute, n)
+	}
+
+	// The code attribute of a currency field, e.g. amountCurrency.
+	if field, ok := strings.CutSuffix(attribute, "Currency"); ok && v.fieldType(entity, field) == "currency" {
+		code, ok := value.(string)
+		if !ok {
+			return "valid"
+		}
+		if v.settings != nil && len(v.settings.CurrencyList) > 0 && !slices.Contains(v.settings.CurrencyList, code) {
+			return "valid"
+		}
+	}
+	return ""
+}
+
+// allowsOption reports whether an enum field accepts the option. Fields without
+// options in the metadata, or allowing custom options, accept any value.
+func (v *FieldValidator) allowsOption(entity, field, option string) bool {
+	if allow, _ := v.fieldParam(entity, field, "allowCustomOptions").(bool); allow {
+		return true
+	}
+	options, _ := v.fieldParam(entity, field, "options").([]any)
+	if len(options) == 0 {
+		return true
+	}
+	return slices.Contains(options, any(option))
+}
+
+func (v *FieldValidator) checkRange(entity, field string, n float64) string {
+	if lo, ok := toFloat(v.fieldParam(entity, field, "min")); ok && n < lo {
+		return "min"
+	}
+	if hi, ok := toFloat(v.fieldParam(entity, field, "max")); ok && n > hi {
+		return "max"
+	}
+	return ""
+}
+
+func (v *FieldValidator) fieldType(entity, field string) string {
+	t, _ := v.fieldParam(entity, field, "type").(string)
+	return t
+}
+
+func (v *FieldValidator) fieldParam(entity, field, param string) any {
+	return lookupPath(v.metadata, "entityDefs", entity, "fields", field, param)
+}
+
+// toFloat converts the numeric types a payload may hold.
+func toFloat(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toStrings converts a []string or a []any of strings.
+func toStrings(value any) ([]string, bool) {
+	switch items := value.(type) {
+	case []string:
+		return items, true
+	case []any:
+		out := make([]string, len(items))
+		for i, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			out[i] = s
+		}
+		return out, true
+	}
+	return nil, false
+}
@@ -0,0 +1,41 @@
+package espoclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// subscriptionPath returns the path of the current user's subscription to a
+// record's stream.
+func subscriptionPath(entity, id string) string {
+	return entity + "/" + url.PathEscape(id) + "/subscription"
+}
+
+// Follow subscribes the API user to the stream of a record, so they are notified
+// of its updates. The entity type must have the stream enabled.
+func (c *Client) Follow(ctx context.Context, entity, id string) error {
+	_, err := c.request(ctx, MethodPut, subscriptionPath(entity, id), nil, nil)
+	return err
+}
+
+// Unfollow unsubscribes the API user from the stream of a record.
+func (c *Client) Unfollow(ctx context.Context, entity, id string) error {
+	_, err := c.request(ctx, MethodDelete, subscriptionPath(entity, id), nil, nil)
+	return err
+}
+
+// ListFollowers returns the users following a record.
+func (c *Client) ListFollowers(ctx context.Context, entity, id string, params *SearchParams) ([]map[string]any, error) {
+	return c.ListRelated(ctx, entity, id, "followers", params)
+}
+
+// AddFollowers subscribes users to the stream of a record. The API user needs
+// edit access to the record, or to be an admin, to add other users.
+func (c *Client) AddFollowers(ctx context.Context, entity, id string, userIDs ...string) error {
+	return c.link(ctx, entity, id, "followers", userIDs)
+}
+
+// RemoveFollowers unsubscribes users from the stream of a record.
+func (c *Client) RemoveFollowers(ctx context.Context, entity, id string, userIDs ...string) error {
+	return c.unlink(ctx, entity, id, "followers", userIDs)
+}
@@ -0,0 +1,62 @@
+package espoclient
+
+import "context"
+
+// ForEach streams the records of an entity list to fn one at a time, decoded into T,
+// fetching pages on demand so memory use stays bounded regardless of the list size.
+// It stops at the first error returned by fn, or when ctx is cancelled, and returns that error.
+//
+//	err := espoclient.ForEach(ctx, client, "Lead", params, func(lead Lead) error {
+//		return process(lead)
+//	})
+func ForEach[T any](ctx context.Context, c *Client, entity string, params *SearchParams, fn func(T) error) error {
+	it := c.newIterator(ctx, entity, params)
+	for it.Next() {
+		var record T
+		if err := it.Decode(&record); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// Result carries either a value or the error that ended a stream.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// ListChan streams the records of an entity list over a channel buffered to hold
+// buffer records. Pages are only fetched as the consumer keeps up, giving natural
+// backpressure when records are fanned out to workers. The channel is closed when the
+// list is exhausted; a failure is delivered as a final Result with Err set.
+//
+// Consumers must drain the channel or cancel ctx, otherwise the producing goroutine leaks.
+func ListChan[T any](ctx context.Context, c *Client, entity string, params *SearchParams, buffer int) <-chan Result[T] {
+	ch := make(chan Result[T], max(buffer, 0))
+	go func() {
+		defer close(ch)
+		send := func(r Result[T]) bool {
+			select {
+			case ch <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		err := ForEach(ctx, c, entity, params, func(record T) error {
+			if !send(Result[T]{Value: record}) {
+				return ctx.Err()
+			}
+			return nil
+		})
+		if err != nil {
+			send(Result[T]{Err: err})
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,40 @@
+package espoclient
+
+import "context"
+
+// GetAs fetches path (e.g. "Lead/"+id or "App/user") and decodes the response into T,
+// such as a struct generated by espogen.
+//
+//	lead, err := espoclient.GetAs[Lead](ctx, client, "Lead/"+id)
+func GetAs[T any](ctx context.Context, c *Client, path string) (T, error) {
+	var v T
+	resp, err := c.request(ctx, MethodGet, path, nil, nil)
+	if err != nil {
+		return v, err
+	}
+	if err := resp.GetParsedBody(&v); err != nil {
+		return v, &EspoError{Message: "failed to parse response", Cause: err}
+	}
+	return v, nil
+}
+
+// ListAs returns the records of a collection endpoint (e.g. "Lead" or
+// "Account/"+id+"/contacts") matching params, decoded into T. All pages are
+// fetched, up to params.Limit.
+//
+//	leads, err := espoclient.ListAs[Lead](ctx, client, "Lead", params)
+func ListAs[T any](ctx context.Context, c *Client, path string, params *SearchParams) ([]T, error) {
+	var records []T
+	it := c.newIterator(ctx, path, params)
+	for it.Next() {
+		var record T
+		if err := it.Decode(&record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
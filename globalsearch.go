@@ -0,0 +1,69 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// GlobalSearchOptions pages GlobalSearch.
+type GlobalSearchOptions struct {
+	Offset  int
+	MaxSize int // defaultPageSize if zero
+}
+
+// SearchMatch is a record found by GlobalSearch. All its attributes are in Raw.
+type SearchMatch struct {
+	EntityType string `json:"_scope"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+
+	Raw map[string]any `json:"-"`
+}
+
+// UnmarshalJSON decodes the common attributes and keeps all of them in Raw.
+func (m *SearchMatch) UnmarshalJSON(data []byte) error {
+	type plain SearchMatch
+	if err := json.Unmarshal(data, (*plain)(m)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &m.Raw)
+}
+
+// GlobalSearchResult is one page of GlobalSearch matches.
+type GlobalSearchResult struct {
+	Total int
+	List  []SearchMatch // best matches first
+}
+
+// ByEntityType groups the matches by entity type, keeping their order.
+func (r *GlobalSearchResult) ByEntityType() map[string][]SearchMatch {
+	groups := map[string][]SearchMatch{}
+	for _, m := range r.List {
+		groups[m.EntityType] = append(groups[m.EntityType], m)
+	}
+	return groups
+}
+
+// GlobalSearch searches the records of all entity types enabled for global search
+// in the CRM settings, as the search box of the UI does. opts may be nil.
+func (c *Client) GlobalSearch(ctx context.Context, query string, opts *GlobalSearchOptions) (*GlobalSearchResult, error) {
+	if opts == nil {
+		opts = &GlobalSearchOptions{}
+	}
+	size := opts.MaxSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	page, err := c.listPage(ctx, "GlobalSearch", url.Values{"q": {query}}, opts.Offset, size)
+	if err != nil {
+		return nil, err
+	}
+	result := &GlobalSearchResult{Total: page.Total, List: make([]SearchMatch, len(page.List))}
+	for i, raw := range page.List {
+		if err := json.Unmarshal(raw, &result.List[i]); err != nil {
+			return nil, &EspoError{Message: "failed to parse search match", Cause: err}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,219 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+const (
+	defaultFetchDepth       = 3
+	defaultFetchConcurrency = 8
+)
+
+// FetchSpec describes a graph fetch: root records plus related records resolved
+// through links, assembled into nested documents.
+//
+//	spec := espoclient.FetchSpec{
+//		Entity: "Account",
+//		ID:     accountID,
+//		Fields: []string{"name", "industry"},
+//		Links: []espoclient.LinkSpec{
+//			{Name: "contacts", Fields: []string{"name", "emailAddress"}},
+//			{Name: "opportunities", Params: &espoclient.SearchParams{OrderBy: "closeDate"}},
+//		},
+//	}
+//	docs, err := client.Fetch(ctx, spec)
+type FetchSpec struct {
+	Entity string
+	ID     string        // fetch this record; if empty, the records matching Params are fetched
+	Params *SearchParams // filters and ordering of the root list
+	Fields []string      // attributes to return; all if empty
+	Links  []LinkSpec
+
+	MaxDepth    int // maximum nesting of Links; defaultFetchDepth if zero
+	Concurrency int // maximum requests in flight; defaultFetchConcurrency if zero
+}
+
+// LinkSpec describes related records to attach to each record under the link name.
+type LinkSpec struct {
+	Name   string
+	Entity string        // entity type of the related records; looked up in metadata if empty and Links is set
+	Params *SearchParams // filters and ordering of the related list
+	Fields []string      // attributes to return; all if empty
+	Links  []LinkSpec
+}
+
+// Fetch resolves the spec with concurrent requests and returns the assembled documents:
+// each record carries its related records under the link name, recursively.
+// If spec.ID is set, the result holds exactly that record.
+func (c *Client) Fetch(ctx context.Context, spec FetchSpec) ([]map[string]any, error) {
+	maxDepth := spec.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultFetchDepth
+	}
+	if depth := linkDepth(spec.Links); depth > maxDepth {
+		return nil, &EspoError{Message: fmt.Sprintf("fetch spec nests links %d levels deep, limit is %d", depth, maxDepth)}
+	}
+	concurrency := spec.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultFetchConcurrency
+	}
+
+	var roots []map[string]any
+	if spec.ID != "" {
+		var query any
+		if len(spec.Fields) > 0 {
+			query = (&SearchParams{Select: spec.Fields}).Values()
+		}
+		resp, err := c.request(ctx, MethodGet, spec.Entity+"/"+url.PathEscape(spec.ID), query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var record map[string]any
+		if err := resp.GetParsedBody(&record); err != nil {
+			return nil, &EspoError{Message: "failed to parse record", Cause: err}
+		}
+		roots = []map[string]any{record}
+	} else {
+		var err error
+		roots, err = c.listAll(ctx, spec.Entity, withSelect(spec.Params, spec.Fields))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	g := &graphFetch{client: c, sem: make(chan struct{}, concurrency), cancel: cancel}
+	g.resolve(ctx, spec.Entity, roots, spec.Links)
+	if g.err != nil {
+		return nil, g.err
+	}
+	return roots, nil
+}
+
+// FetchInto resolves the spec and decodes the result into v, which should point to
+// a struct (when spec.ID is set) or a slice of structs.
+func (c *Client) FetchInto(ctx context.Context, spec FetchSpec, v any) error {
+	docs, err := c.Fetch(ctx, spec)
+	if err != nil {
+		return err
+	}
+	var doc any = docs
+	if spec.ID != "" {
+		doc = docs[0]
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return &EspoError{Message: "failed to encode fetched documents", Cause: err}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return &EspoError{Message: "failed to decode fetched documents", Cause: err}
+	}
+	return nil
+}
+
+// graphFetch holds the state shared by all requests of one Fetch call.
+type graphFetch struct {
+	client *Client
+	sem    chan struct{}
+	cancel context.CancelFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+// resolve attaches the related records of every link to every record, recursing
+// into nested links. The first error cancels the remaining requests.
+func (g *graphFetch) resolve(ctx context.Context, entity string, records []map[string]any, links []LinkSpec) {
+	var wg sync.WaitGroup
+	for _, record := range records {
+		id, _ := record["id"].(string)
+		if id == "" {
+			continue
+		}
+		for _, link := range links {
+			wg.Add(1)
+			go func(record map[string]any, link LinkSpec) {
+				defer wg.Done()
+				related, err := g.fetchRelated(ctx, entity, id, link)
+				if err == nil && len(link.Links) > 0 {
+					var foreign string
+					foreign, err = g.foreignEntity(ctx, entity, link)
+					if err == nil {
+						g.resolve(ctx, foreign, related, link.Links)
+					}
+				}
+				g.mu.Lock()
+				defer g.mu.Unlock()
+				if err != nil {
+					if g.err == nil {
+						g.err = err
+						g.cancel()
+					}
+					return
+				}
+				if related == nil {
+					related = []map[string]any{}
+				}
+				record[link.Name] = related
+			}(record, link)
+		}
+	}
+	wg.Wait()
+}
+
+// fetchRelated lists the related records of one link, bounded by the semaphore.
+func (g *graphFetch) fetchRelated(ctx context.Context, entity, id string, link LinkSpec) ([]map[string]any, error) {
+	select {
+	case g.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-g.sem }()
+	return g.client.ListRelated(ctx, entity, id, link.Name, withSelect(link.Params, link.Fields))
+}
+
+// foreignEntity returns the entity type a link points to.
+func (g *graphFetch) foreignEntity(ctx context.Context, entity string, link LinkSpec) (string, error) {
+	if link.Entity != "" {
+		return link.Entity, nil
+	}
+	metadata, err := g.client.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	foreign, _ := lookupPath(metadata, "entityDefs", entity, "links", link.Name, "entity").(string)
+	if foreign == "" {
+		return "", &EspoError{Message: fmt.Sprintf("cannot determine the entity type of link %s.%s", entity, link.Name)}
+	}
+	return foreign, nil
+}
+
+// withSelect returns a copy of params with Select set to fields (if any).
+func withSelect(params *SearchParams, fields []string) *SearchParams {
+	if len(fields) == 0 {
+		return params
+	}
+	p := SearchParams{}
+	if params != nil {
+		p = *params
+	}
+	p.Select = fields
+	return &p
+}
+
+// linkDepth returns how many levels of links are nested.
+func linkDepth(links []LinkSpec) int {
+	depth := 0
+	for _, link := range links {
+		if d := 1 + linkDepth(link.Links); d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
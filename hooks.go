@@ -0,0 +1,133 @@
+package espoclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Operation identifies the kind of write a RecordHook is invoked for.
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+)
+
+// RecordHook is called with the payload of a create or update request before it is sent.
+// The record is a copy of the caller's payload and may be modified in place.
+// Returning an error aborts the request. Numbers of typed (struct) payloads are
+// json.Number values, so that large integers are sent unchanged.
+type RecordHook func(ctx context.Context, op Operation, entity string, record map[string]any) error
+
+// AddRecordHook registers a hook that runs before every create (POST {Entity})
+// and update (PUT or PATCH {Entity}/{id}) request whose payload is a map.
+// Hooks run in the order they were added.
+func (c *Client) AddRecordHook(hook RecordHook) *Client {
+	c.recordHooks = append(c.recordHooks, hook)
+	return c
+}
+
+// recordOperation reports whether method and path address a record create or update,
+// returning the operation and the entity type.
+func recordOperation(method, path string) (Operation, string, bool) {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case method == MethodPost && len(segments) == 1 && segments[0] != "":
+		return OperationCreate, segments[0], true
+	case (method == MethodPut || method == MethodPatch) && len(segments) == 2 && segments[0] != "" && segments[1] != "":
+		return OperationUpdate, segments[0], true
+	}
+	return "", "", false
+}
+
+// applyRecordHooks runs the registered hooks for create and update requests.
+// It returns the (possibly replaced) request data.
+func (c *Client) applyRecordHooks(ctx context.Context, method, path string, data any) (any, error) {
+	if len(c.recordHooks) == 0 {
+		return data, nil
+	}
+	op, entity, ok := recordOperation(method, path)
+	if !ok {
+		return data, nil
+	}
+	if _, isStream := data.(io.Reader); isStream {
+		return data, nil // raw bodies are not records
+	}
+	record, ok := asRecord(data)
+	if !ok {
+		// Typed records (structs) are converted so hooks see the JSON attributes.
+		if record, ok = structToRecord(data); !ok {
+			return data, nil
+		}
+	}
+
+	// Work on a copy so hooks never modify the caller's map.
+	copied := make(map[string]any, len(record))
+	for k, v := range record {
+		copied[k] = v
+	}
+	for _, hook := range c.recordHooks {
+		if err := hook(ctx, op, entity, copied); err != nil {
+			return nil, &EspoError{Message: "record hook failed", Cause: err}
+		}
+	}
+	return copied, nil
+}
+
+// structToRecord converts a struct (or pointer to one) into its JSON attribute map.
+// Numbers are decoded as json.Number: float64 would corrupt integers above 2^53,
+// such as large IDs and amounts.
+func structToRecord(data any) (map[string]any, bool) {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.UseNumber()
+	var record map[string]any
+	if err := dec.Decode(&record); err != nil {
+		return nil, false
+	}
+	return record, true
+}
+
+// SlowRequestFunc is called for requests that took longer than the configured threshold.
+// path is the API path without the query string.
+type SlowRequestFunc func(method, path string, duration time.Duration)
+
+// OnSlowRequest registers fn to be called, synchronously after the request completes,
+// whenever a request (including any retries) takes longer than threshold.
+// Failed requests are reported too. Passing a nil fn removes the callback.
+func (c *Client) OnSlowRequest(threshold time.Duration, fn SlowRequestFunc) *Client {
+	c.slowThreshold = threshold
+	c.onSlowRequest = fn
+	return c
+}
+
+// reportSlowRequest calls the slow request callback if the request started at start exceeded the threshold.
+func (c *Client) reportSlowRequest(method, path string, start time.Time) {
+	if c.onSlowRequest == nil {
+		return
+	}
+	if duration := c.clock.Now().Sub(start); duration > c.slowThreshold {
+		if i := strings.IndexByte(path, '?'); i >= 0 {
+			path = path[:i]
+		}
+		c.onSlowRequest(method, strings.TrimPrefix(path, "/"), duration)
+	}
+}
@@ -0,0 +1,50 @@
+package espoclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// Typed payloads converted into records for hooks and WithVersionNumber must keep
+// integers above 2^53 exact.
+func TestStructPayloadKeepsLargeIntegers(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.AddRecordHook(func(ctx context.Context, op espoclient.Operation, entity string, record map[string]any) error {
+		return nil
+	})
+
+	type invoice struct {
+		ExternalID int64 `json:"externalId"`
+	}
+	const want = `"externalId":9007199254740993`
+	ctx := context.Background()
+	if _, err := client.CreateEntity(ctx, "Invoice", invoice{ExternalID: 9007199254740993}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, want) {
+		t.Errorf("create body = %s, want %s", body, want)
+	}
+	if _, err := client.RequestWithContext(ctx, espoclient.MethodPut, "Invoice/1", invoice{ExternalID: 9007199254740993}, nil, espoclient.WithVersionNumber(3)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, want) || !strings.Contains(body, `"versionNumber":3`) {
+		t.Errorf("update body = %s, want %s and the version number", body, want)
+	}
+}
@@ -0,0 +1,105 @@
+package espoclient
+
+import "context"
+
+// Translator looks up the labels of entity types, fields, links and enum options
+// in one language, as shown in the UI, e.g. for the headers of a report. It holds
+// a snapshot of metadata and translations and is safe for concurrent use.
+type Translator struct {
+	i18n  map[string]any
+	enums *EnumMapper
+}
+
+// NewTranslator builds a Translator from already fetched metadata and translations.
+func NewTranslator(metadata, i18n map[string]any) *Translator {
+	return &Translator{i18n: i18n, enums: NewEnumMapper(metadata, i18n)}
+}
+
+// Translator returns a translator over the cached metadata and the translations
+// for language (empty for the user's language). Keep it around rather than
+// calling this per label.
+func (c *Client) Translator(ctx context.Context, language string) (*Translator, error) {
+	metadata, err := c.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i18n, err := c.GetI18n(ctx, language)
+	if err != nil {
+		return nil, err
+	}
+	return NewTranslator(metadata, i18n), nil
+}
+
+// Label returns the translation of name in a category ("fields", "links",
+// "labels", ...) of scope, falling back to the Global scope and then to name.
+func (t *Translator) Label(scope, category, name string) string {
+	if label, ok := lookupPath(t.i18n, scope, category, name).(string); ok && label != "" {
+		return label
+	}
+	if label, ok := lookupPath(t.i18n, "Global", category, name).(string); ok && label != "" {
+		return label
+	}
+	return name
+}
+
+// Field returns the label of a field.
+func (t *Translator) Field(entity, field string) string {
+	return t.Label(entity, "fields", field)
+}
+
+// Link returns the label of a link.
+func (t *Translator) Link(entity, link string) string {
+	return t.Label(entity, "links", link)
+}
+
+// EntityName returns the singular name of an entity type, e.g. "Opportunity".
+func (t *Translator) EntityName(entity string) string {
+	return t.Label("Global", "scopeNames", entity)
+}
+
+// EntityNamePlural returns the plural name of an entity type, e.g. "Opportunities".
+func (t *Translator) EntityNamePlural(entity string) string {
+	return t.Label("Global", "scopeNamesPlural", entity)
+}
+
+// Option returns the label of an enum option (see EnumMapper.Label).
+func (t *Translator) Option(entity, field, value string) string {
+	return t.enums.Label(entity, field, value)
+}
+
+// Fields returns the labels of fields, e.g. for the header row of an export.
+func (t *Translator) Fields(entity string, fields []string) []string {
+	labels := make([]string, len(fields))
+	for i, field := range fields {
+		labels[i] = t.Field(entity, field)
+	}
+	return labels
+}
+
+// Record returns a copy of a record keyed by field labels, with the values of
+// enum, multi-enum and checklist fields replaced by their option labels. Fields
+// with the same label overwrite each other, so pick the fields to translate with
+// SearchParams.Select.
+func (t *Translator) Record(entity string, record map[string]any) map[string]any {
+	translated := make(map[string]any, len(record))
+	for field, value := range record {
+		if len(t.enums.Options(entity, field)) > 0 {
+			switch v := value.(type) {
+			case string:
+				value = t.Option(entity, field, v)
+			case []any:
+				labels := make([]any, len(v))
+				for i, elem := range v {
+					if s, ok := elem.(string); ok {
+						labels[i] = t.Option(entity, field, s)
+					} else {
+						labels[i] = elem
+					}
+				}
+				value = labels
+			}
+		}
+		translated[t.Field(entity, field)] = value
+	}
+	return translated
+}
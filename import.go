@@ -0,0 +1,211 @@
+package espoclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// defaultImportPollInterval is used by WaitForImport when interval is not positive.
+const defaultImportPollInterval = 2 * time.Second
+
+// ImportAction is what an import does with the rows of the file.
+type ImportAction string
+
+// Import actions supported by EspoCRM.
+const (
+	ImportCreate          ImportAction = "create"
+	ImportCreateAndUpdate ImportAction = "createAndUpdate"
+	ImportUpdate          ImportAction = "update"
+)
+
+// Import statuses reported in ImportResult.Status.
+const (
+	ImportStatusPending   = "Pending"
+	ImportStatusInProcess = "In Process"
+	ImportStatusComplete  = "Complete"
+	ImportStatusFailed    = "Failed"
+	ImportStatusStandby   = "Standby" // stopped until resumed in the UI
+)
+
+// ImportParams configures an import. Empty format settings take the defaults shown.
+type ImportParams struct {
+	Action ImportAction // ImportCreate if empty
+	// Fields maps the columns of the file, in order, to attributes; an empty string
+	// skips the column.
+	Fields []string
+	// HeaderRow skips the first line of the file, which holds column names.
+	HeaderRow bool
+	// UpdateBy lists the indexes of the columns identifying existing records for
+	// ImportCreateAndUpdate and ImportUpdate.
+	UpdateBy []int
+	// DefaultValues are set on every imported record.
+	DefaultValues map[string]any
+
+	Delimiter        string // ","
+	TextQualifier    string // "\""
+	DateFormat       string // "YYYY-MM-DD"
+	TimeFormat       string // "HH:mm"
+	TimeZone         string // "UTC"
+	DecimalMark      string // "."
+	PersonNameFormat string // "f l"
+	Currency         string // the system default currency
+
+	SkipDuplicateCheck bool
+	// Silent skips workflows, formulas and stream notes for imported records.
+	Silent bool
+	// PollInterval is the pause between status checks of Import; 2s if zero.
+	PollInterval time.Duration
+}
+
+// ImportResult is the state of an import job.
+type ImportResult struct {
+	ID         string
+	Status     string
+	Created    int
+	Updated    int
+	Duplicates int
+}
+
+// Import imports the CSV file into records of the entity: it uploads the file,
+// runs the import and waits for it to finish.
+func (c *Client) Import(ctx context.Context, entity string, file io.Reader, params ImportParams) (*ImportResult, error) {
+	attachmentID, err := c.UploadImportFile(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	importID, err := c.RunImport(ctx, entity, attachmentID, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitForImport(ctx, importID, params.PollInterval)
+}
+
+// UploadImportFile uploads a CSV file for RunImport and returns its attachment ID.
+func (c *Client) UploadImportFile(ctx context.Context, file io.Reader) (string, error) {
+	resp, err := c.request(ctx, MethodPost, "Import/file", file, map[string]string{"Content-Type": "text/csv"})
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		AttachmentID string `json:"attachmentId"`
+	}
+	if err := resp.GetParsedBody(&result); err != nil {
+		return "", &EspoError{Message: "failed to parse import file upload result", Cause: err}
+	}
+	return result.AttachmentID, nil
+}
+
+// RunImport starts importing an uploaded file into records of the entity and
+// returns the ID of the Import record tracking it.
+func (c *Client) RunImport(ctx context.Context, entity, attachmentID string, params ImportParams) (string, error) {
+	or := func(value, fallback string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	}
+	updateBy := params.UpdateBy
+	if updateBy == nil {
+		updateBy = []int{}
+	}
+	defaultValues := params.DefaultValues
+	if defaultValues == nil {
+		defaultValues = map[string]any{}
+	}
+	body := map[string]any{
+		"entityType":            entity,
+		"attachmentId":          attachmentID,
+		"action":                or(string(params.Action), string(ImportCreate)),
+		"attributeList":         params.Fields,
+		"headerRow":             params.HeaderRow,
+		"updateBy":              updateBy,
+		"defaultValues":         defaultValues,
+		"delimiter":             or(params.Delimiter, ","),
+		"textQualifier":         or(params.TextQualifier, "\""),
+		"dateFormat":            or(params.DateFormat, "YYYY-MM-DD"),
+		"timeFormat":            or(params.TimeFormat, "HH:mm"),
+		"timezone":              or(params.TimeZone, "UTC"),
+		"decimalMark":           or(params.DecimalMark, "."),
+		"personNameFormat":      or(params.PersonNameFormat, "f l"),
+		"skipDuplicateChecking": params.SkipDuplicateCheck,
+		"silentMode":            params.Silent,
+	}
+	if params.Currency != "" {
+		body["currency"] = params.Currency
+	}
+
+	resp, err := c.request(ctx, MethodPost, "Import", body, nil)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := resp.GetParsedBody(&result); err != nil {
+		return "", &EspoError{Message: "failed to parse import result", Cause: err}
+	}
+	return result.ID, nil
+}
+
+// ImportStatus returns the state of an import. Once it has stopped (Complete,
+// Failed or Standby), the result also holds the number of records it created,
+// updated and found to be duplicates, which takes three more requests; while it
+// runs, the counts are zero.
+func (c *Client) ImportStatus(ctx context.Context, importID string) (*ImportResult, error) {
+	path := "Import/" + url.PathEscape(importID)
+	record, err := c.getObject(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &ImportResult{ID: importID}
+	result.Status, _ = record["status"].(string)
+	switch result.Status {
+	case ImportStatusComplete, ImportStatusFailed, ImportStatusStandby:
+	default:
+		return result, nil
+	}
+
+	counts := []struct {
+		link string
+		n    *int
+	}{{"imported", &result.Created}, {"updated", &result.Updated}, {"duplicates", &result.Duplicates}}
+	for _, count := range counts {
+		page, err := c.listPage(ctx, path+"/"+count.link, url.Values{"select": {"id"}}, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		*count.n = page.Total
+	}
+	return result, nil
+}
+
+// WaitForImport polls an import every interval (2s if zero) until it stops and
+// returns its result. A failed import, or one on standby, which does not progress
+// until it is resumed, is returned with an error.
+func (c *Client) WaitForImport(ctx context.Context, importID string, interval time.Duration) (*ImportResult, error) {
+	if interval <= 0 {
+		interval = defaultImportPollInterval
+	}
+	for {
+		result, err := c.ImportStatus(ctx, importID)
+		if err != nil {
+			return nil, err
+		}
+		switch result.Status {
+		case ImportStatusComplete:
+			return result, nil
+		case ImportStatusFailed:
+			return result, &EspoError{Message: fmt.Sprintf("import %s failed", importID)}
+		case ImportStatusStandby:
+			return result, &EspoError{Message: fmt.Sprintf("import %s is on standby", importID)}
+		}
+		select {
+		case <-c.clock.After(interval):
+		case <-ctx.Done():
+			return nil, &EspoError{Message: "import wait aborted", Cause: ctx.Err()}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package espoclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// importServer serves an import whose status is taken from statuses, one per poll
+// (the last one repeating), and counts the requests for its related records.
+func importServer(statuses ...string) (*httptest.Server, *int) {
+	polls, listings := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Count(r.URL.Path, "/") > 4 { // /api/v1/Import/{id}/{link}
+			listings++
+			w.Write([]byte(`{"total":3,"list":[]}`))
+			return
+		}
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		w.Write([]byte(`{"id":"i1","status":"` + status + `"}`))
+	}))
+	return srv, &listings
+}
+
+func TestWaitForImportCountsOnceDone(t *testing.T) {
+	srv, listings := importServer("Pending", "In Process", "Complete")
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := client.WaitForImport(context.Background(), "i1", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != espoclient.ImportStatusComplete || result.Created != 3 {
+		t.Errorf("result = %+v", result)
+	}
+	if *listings != 3 {
+		t.Errorf("%d count requests, want 3 (only after the import completed)", *listings)
+	}
+}
+
+func TestWaitForImportStandby(t *testing.T) {
+	srv, _ := importServer("In Process", "Standby")
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := client.WaitForImport(ctx, "i1", time.Millisecond)
+	if err == nil || ctx.Err() != nil {
+		t.Fatalf("err = %v, ctx err = %v; want an error before the deadline", err, ctx.Err())
+	}
+	if result == nil || result.Status != espoclient.ImportStatusStandby {
+		t.Errorf("result = %+v", result)
+	}
+}
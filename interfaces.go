@@ -0,0 +1,215 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// The interfaces below split the Client API by capability, so applications can
+// depend on exactly what they use and substitute test doubles. *Client implements all of them.
+
+// Requester sends raw API requests.
+type Requester interface {
+	Request(method, path string, data any, headers map[string]string, opts ...RequestOption) (*Response, error)
+	RequestWithContext(ctx context.Context, method, path string, data any, headers map[string]string, opts ...RequestOption) (*Response, error)
+	RequestStream(ctx context.Context, method, path string, data any, headers map[string]string, opts ...RequestOption) (*StreamResponse, error)
+	Do(ctx context.Context, spec *RequestSpec) (*Response, error)
+}
+
+// RecordReader reads, lists and searches records.
+type RecordReader interface {
+	ReadEntity(ctx context.Context, entity, id string) (map[string]any, error)
+	ListEntities(ctx context.Context, entity string, params *SearchParams, offset int) (*EntityList, error)
+	Iterate(ctx context.Context, entity string, params *SearchParams) *Iterator
+	ListAll(ctx context.Context, entity string, params *SearchParams) ([]map[string]any, error)
+	Exists(ctx context.Context, entity, id string) (bool, error)
+	FindPossibleDuplicates(ctx context.Context, entity string, data map[string]any) ([]map[string]any, error)
+	GetCopyAttributes(ctx context.Context, entity, id string) (map[string]any, error)
+	FindByEmailAddress(ctx context.Context, entity, email string) ([]map[string]any, error)
+	FindByPhoneNumber(ctx context.Context, entity, phone string) ([]map[string]any, error)
+	GlobalSearch(ctx context.Context, query string, opts *GlobalSearchOptions) (*GlobalSearchResult, error)
+	IterateRelated(ctx context.Context, entity, id, link string, params *SearchParams) *Iterator
+	ListRelated(ctx context.Context, entity, id, link string, params *SearchParams) ([]map[string]any, error)
+	Fetch(ctx context.Context, spec FetchSpec) ([]map[string]any, error)
+	FetchInto(ctx context.Context, spec FetchSpec, v any) error
+	Enrich(ctx context.Context, records []map[string]any, link, foreignEntity string, fields ...string) error
+}
+
+// RecordWriter creates, updates, deletes and relates single records.
+type RecordWriter interface {
+	CreateEntity(ctx context.Context, entity string, data any) (map[string]any, error)
+	UpdateEntity(ctx context.Context, entity, id string, data any) (map[string]any, error)
+	UpdateFields(ctx context.Context, entity, id string, original, updated map[string]any) (map[string]any, error)
+	UpdateIfUnchanged(ctx context.Context, entity, id string, original, updated map[string]any) (map[string]any, error)
+	DeleteEntity(ctx context.Context, entity, id string) error
+	UpsertEntity(ctx context.Context, entity, matchField string, record map[string]any, opts UpsertOptions) (map[string]any, bool, error)
+	LinkRecords(ctx context.Context, entity, id, link string, foreignIDs ...string) error
+	UnlinkRecords(ctx context.Context, entity, id, link string, foreignIDs ...string) error
+}
+
+// BulkClient writes many records at once: client-side bulk operations and
+// server-side mass actions.
+type BulkClient interface {
+	BulkCreate(ctx context.Context, entity string, records []map[string]any, opts BulkOptions) *BulkReport
+	BulkUpsert(ctx context.Context, entity, matchField string, records []map[string]any, opts BulkOptions) *BulkReport
+	MassRelate(ctx context.Context, entity, id, link string, params *SearchParams) error
+	MassRelateAll(ctx context.Context, entity, id, link string) error
+	MassUpdate(ctx context.Context, entity string, selection MassSelection, data map[string]any) (*MassActionResult, error)
+	MassDelete(ctx context.Context, entity string, selection MassSelection) (*MassActionResult, error)
+	MassRecalculate(ctx context.Context, entity string, selection MassSelection) (*MassActionResult, error)
+	MassActionStatus(ctx context.Context, jobID string) (string, error)
+}
+
+// JobClient reads background jobs.
+type JobClient interface {
+	GetJob(ctx context.Context, id string) (*Job, error)
+	WaitForJob(ctx context.Context, jobID string, pollInterval time.Duration) (*Job, error)
+}
+
+// ActionClient calls controller and record actions.
+type ActionClient interface {
+	Action(ctx context.Context, entity, id, name string, payload map[string]any) (json.RawMessage, error)
+	RecordAction(ctx context.Context, entity, id, action string, data map[string]any) (json.RawMessage, error)
+	ConvertLead(ctx context.Context, leadID string, records map[string]map[string]any) (map[string]any, error)
+	GetLeadConvertAttributes(ctx context.Context, leadID string) (map[string]any, error)
+	MergeRecords(ctx context.Context, entity, targetID string, sourceIDs []string, attributes map[string]any) error
+}
+
+// RecordClient combines the record interfaces, for code that uses most of them;
+// prefer the narrower ones otherwise.
+type RecordClient interface {
+	Requester
+	RecordReader
+	RecordWriter
+	BulkClient
+	JobClient
+	ActionClient
+}
+
+// AttachmentClient uploads and downloads files.
+type AttachmentClient interface {
+	UploadAttachment(ctx context.Context, file io.Reader, meta AttachmentMeta) (string, error)
+	DownloadAttachment(ctx context.Context, id string, w io.Writer) (*Download, error)
+}
+
+// ExportClient exports records to files and imports them from files.
+type ExportClient interface {
+	Export(ctx context.Context, entity string, req ExportRequest) (io.ReadCloser, error)
+	Import(ctx context.Context, entity string, file io.Reader, params ImportParams) (*ImportResult, error)
+	UploadImportFile(ctx context.Context, file io.Reader) (string, error)
+	RunImport(ctx context.Context, entity, attachmentID string, params ImportParams) (string, error)
+	ImportStatus(ctx context.Context, importID string) (*ImportResult, error)
+	WaitForImport(ctx context.Context, importID string, interval time.Duration) (*ImportResult, error)
+}
+
+// StreamClient posts to and reads record streams and manages their followers.
+type StreamClient interface {
+	PostToStream(ctx context.Context, entity, id string, note NewNote) (*Note, error)
+	GetStream(ctx context.Context, entity, id string, params *SearchParams) ([]Note, error)
+	Follow(ctx context.Context, entity, id string) error
+	Unfollow(ctx context.Context, entity, id string) error
+	ListFollowers(ctx context.Context, entity, id string, params *SearchParams) ([]map[string]any, error)
+	AddFollowers(ctx context.Context, entity, id string, userIDs ...string) error
+	RemoveFollowers(ctx context.Context, entity, id string, userIDs ...string) error
+}
+
+// ActivityClient reads the activities of records and users' calendars.
+type ActivityClient interface {
+	GetActivities(ctx context.Context, entity, id string, params *ActivityParams) (*ActivityList, error)
+	GetHistory(ctx context.Context, entity, id string, params *ActivityParams) (*ActivityList, error)
+	GetCalendar(ctx context.Context, params CalendarParams) ([]Activity, error)
+	GetBusyRanges(ctx context.Context, params BusyRangeParams) (map[string][]BusyRange, error)
+	IsBusy(ctx context.Context, start, end time.Time, userIDs ...string) ([]string, error)
+}
+
+// UserAdminClient provisions users and teams.
+type UserAdminClient interface {
+	CreateUser(ctx context.Context, user NewUser) (*CreatedUser, error)
+	SetUserPassword(ctx context.Context, userID, password string) error
+	GenerateUserPassword(ctx context.Context, userID string) error
+	ActivateUser(ctx context.Context, userID string) error
+	DeactivateUser(ctx context.Context, userID string) error
+	AddUserToTeams(ctx context.Context, userID string, teamIDs ...string) error
+	RemoveUserFromTeams(ctx context.Context, userID string, teamIDs ...string) error
+	AssignRoles(ctx context.Context, userID string, roleIDs ...string) error
+	RevokeRoles(ctx context.Context, userID string, roleIDs ...string) error
+	CreateTeam(ctx context.Context, name string, roleIDs ...string) (string, error)
+	FindUserByName(ctx context.Context, userName string) (*UserInfo, error)
+}
+
+// SchemaAdminClient manages custom entity types, links and fields.
+type SchemaAdminClient interface {
+	CreateCustomEntity(ctx context.Context, def EntityDefinition) (string, error)
+	UpdateCustomEntity(ctx context.Context, name string, update EntityDefinitionUpdate) error
+	RemoveCustomEntity(ctx context.Context, name string) error
+	CreateLink(ctx context.Context, def LinkDefinition) error
+	RemoveLink(ctx context.Context, entity, link string) error
+	CreateCustomField(ctx context.Context, entity string, def FieldDefinition) (map[string]any, error)
+	GetCustomField(ctx context.Context, entity, field string) (map[string]any, error)
+	UpdateCustomField(ctx context.Context, entity, field string, changes map[string]any) (map[string]any, error)
+	RemoveCustomField(ctx context.Context, entity, field string) error
+	RebuildSchema(ctx context.Context) error
+}
+
+// EmailClient sends emails.
+type EmailClient interface {
+	SendEmail(ctx context.Context, msg EmailMessage) (*SentEmail, error)
+}
+
+// WebhookClient manages webhook subscriptions.
+type WebhookClient interface {
+	CreateWebhook(ctx context.Context, event, targetURL string) (*Webhook, error)
+	ListWebhooks(ctx context.Context, params *SearchParams) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+}
+
+// MetadataClient reads application metadata and translations.
+type MetadataClient interface {
+	Metadata(ctx context.Context) (map[string]any, error)
+	GetMetadata(ctx context.Context) (map[string]any, error)
+	InvalidateMetadata()
+	GetI18n(ctx context.Context, language string) (map[string]any, error)
+	EnumMapper(ctx context.Context, language string) (*EnumMapper, error)
+	Translator(ctx context.Context, language string) (*Translator, error)
+	FieldValidator(ctx context.Context) (*FieldValidator, error)
+	PrimaryFilters(ctx context.Context, entity string) ([]string, error)
+	GetLayout(ctx context.Context, entity, layoutType string) (json.RawMessage, error)
+	GetDetailLayout(ctx context.Context, entity, layoutType string) (DetailLayout, error)
+	GetListLayout(ctx context.Context, entity, layoutType string) ([]ListColumn, error)
+	GetFieldListLayout(ctx context.Context, entity, layoutType string) ([]string, error)
+}
+
+// AppClient reads information about the authenticated user and the instance, and
+// updates the system settings.
+type AppClient interface {
+	GetAppUser(ctx context.Context) (*AppUser, error)
+	GetSettings(ctx context.Context) (*Settings, error)
+	UpdateSettings(ctx context.Context, update SettingsUpdate) (*Settings, error)
+	ACL(ctx context.Context) (*ACL, error)
+	UserLocation(ctx context.Context) (*time.Location, error)
+	Capabilities(ctx context.Context, entity string) (*Capabilities, error)
+	SavedFilters(ctx context.Context, entity string) ([]SavedFilter, error)
+	SavedFilter(ctx context.Context, entity, name string) (*SavedFilter, error)
+}
+
+var (
+	_ Requester         = (*Client)(nil)
+	_ RecordReader      = (*Client)(nil)
+	_ RecordWriter      = (*Client)(nil)
+	_ BulkClient        = (*Client)(nil)
+	_ JobClient         = (*Client)(nil)
+	_ ActionClient      = (*Client)(nil)
+	_ RecordClient      = (*Client)(nil)
+	_ AttachmentClient  = (*Client)(nil)
+	_ ExportClient      = (*Client)(nil)
+	_ StreamClient      = (*Client)(nil)
+	_ ActivityClient    = (*Client)(nil)
+	_ UserAdminClient   = (*Client)(nil)
+	_ SchemaAdminClient = (*Client)(nil)
+	_ EmailClient       = (*Client)(nil)
+	_ WebhookClient     = (*Client)(nil)
+	_ MetadataClient    = (*Client)(nil)
+	_ AppClient         = (*Client)(nil)
+)
@@ -0,0 +1,80 @@
+package espoclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Bounds of the backoff of WaitForJob.
+const (
+	defaultJobPollInterval = 2 * time.Second // when pollInterval is not positive
+	maxJobPollInterval     = 30 * time.Second
+)
+
+// Statuses of a Job.
+const (
+	JobStatusPending = "Pending"
+	JobStatusReady   = "Ready"
+	JobStatusRunning = "Running"
+	JobStatusSuccess = "Success"
+	JobStatusFailed  = "Failed"
+)
+
+// Job is a background job of the EspoCRM scheduler, e.g. a mass action or an
+// import run in the background. Reading jobs requires an admin API user.
+type Job struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	ExecuteTime    string `json:"executeTime,omitempty"` // UTC, when the job is due
+	ClassName      string `json:"className,omitempty"`
+	ServiceName    string `json:"serviceName,omitempty"`
+	MethodName     string `json:"methodName,omitempty"`
+	TargetType     string `json:"targetType,omitempty"`
+	TargetID       string `json:"targetId,omitempty"`
+	Attempts       int    `json:"attempts,omitempty"` // attempts left after a failure
+	FailedAttempts int    `json:"failedAttempts,omitempty"`
+	CreatedAt      string `json:"createdAt,omitempty"`
+	ModifiedAt     string `json:"modifiedAt,omitempty"`
+}
+
+// Done reports whether the job succeeded or failed.
+func (j *Job) Done() bool {
+	return j.Status == JobStatusSuccess || j.Status == JobStatusFailed
+}
+
+// GetJob fetches a job by ID.
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	return getTyped[Job](ctx, c, "Job/"+url.PathEscape(id))
+}
+
+// WaitForJob polls a job until it succeeds or fails and returns it; a failed job
+// is returned with an error. The wait between polls starts at pollInterval (two
+// seconds if zero) and doubles up to 30 seconds, so long jobs are not polled
+// needlessly often. Cancelling ctx aborts the wait.
+func (c *Client) WaitForJob(ctx context.Context, jobID string, pollInterval time.Duration) (*Job, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultJobPollInterval
+	}
+	interval := pollInterval
+	for {
+		job, err := c.GetJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		switch job.Status {
+		case JobStatusSuccess:
+			return job, nil
+		case JobStatusFailed:
+			return job, &EspoError{Message: fmt.Sprintf("job %s failed", jobID)}
+		}
+		select {
+		case <-c.clock.After(interval):
+		case <-ctx.Done():
+			return nil, &EspoError{Message: "job wait aborted", Cause: ctx.Err()}
+		}
+		interval = min(interval*2, max(maxJobPollInterval, pollInterval))
+	}
+}
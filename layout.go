@@ -0,0 +1,129 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Layout types of an entity type.
+const (
+	LayoutDetail        = "detail"
+	LayoutDetailSmall   = "detailSmall"
+	LayoutEdit          = "edit"
+	LayoutList          = "list"
+	LayoutListSmall     = "listSmall"
+	LayoutFilters       = "filters"
+	LayoutMassUpdate    = "massUpdate"
+	LayoutRelationships = "relationships"
+)
+
+// LayoutPanel is a panel of a detail layout.
+type LayoutPanel struct {
+	Name        string `json:"name,omitempty"`
+	Label       string `json:"label,omitempty"` // translation label
+	CustomLabel string `json:"customLabel,omitempty"`
+	Style       string `json:"style,omitempty"` // "default", "success", ...
+	// TabBreak starts a new tab with this panel, labelled TabLabel.
+	TabBreak bool           `json:"tabBreak,omitempty"`
+	TabLabel string         `json:"tabLabel,omitempty"`
+	Rows     [][]LayoutCell `json:"rows"`
+}
+
+// LayoutCell is a cell of a detail layout row. An empty cell has no Name.
+type LayoutCell struct {
+	Name        string `json:"name,omitempty"`
+	FullWidth   bool   `json:"fullWidth,omitempty"`
+	NoLabel     bool   `json:"noLabel,omitempty"`
+	CustomLabel string `json:"customLabel,omitempty"`
+}
+
+// UnmarshalJSON decodes a cell, which is false if empty.
+func (c *LayoutCell) UnmarshalJSON(data []byte) error {
+	if string(data) == "false" || string(data) == "null" {
+		*c = LayoutCell{}
+		return nil
+	}
+	type plain LayoutCell
+	return json.Unmarshal(data, (*plain)(c))
+}
+
+// DetailLayout is the layout of the detail or edit view: panels of rows of fields.
+type DetailLayout []LayoutPanel
+
+// Fields returns the fields of the layout in display order.
+func (l DetailLayout) Fields() []string {
+	var fields []string
+	for _, panel := range l {
+		for _, row := range panel.Rows {
+			for _, cell := range row {
+				if cell.Name != "" {
+					fields = append(fields, cell.Name)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// ListColumn is a column of a list layout.
+type ListColumn struct {
+	Name        string  `json:"name"`
+	Width       float64 `json:"width,omitempty"`   // percent
+	WidthPx     float64 `json:"widthPx,omitempty"` // pixels
+	Link        bool    `json:"link,omitempty"`    // the column links to the record
+	NotSortable bool    `json:"notSortable,omitempty"`
+	Align       string  `json:"align,omitempty"` // "left" or "right"
+}
+
+// GetLayout returns a layout of the entity type as configured in the Layout
+// Manager, e.g. LayoutDetail or LayoutFilters. The structure depends on the type;
+// GetDetailLayout, GetListLayout and GetFieldListLayout decode the common ones.
+func (c *Client) GetLayout(ctx context.Context, entity, layoutType string) (json.RawMessage, error) {
+	resp, err := c.request(ctx, MethodGet, entity+"/layout/"+url.PathEscape(layoutType), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(resp.Body), nil
+}
+
+// GetDetailLayout returns a detail layout: LayoutDetail, LayoutDetailSmall or
+// LayoutEdit (which falls back to the detail layout on the server).
+func (c *Client) GetDetailLayout(ctx context.Context, entity, layoutType string) (DetailLayout, error) {
+	var layout DetailLayout
+	if err := c.getLayout(ctx, entity, layoutType, &layout); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+// GetListLayout returns a list layout: LayoutList or LayoutListSmall.
+func (c *Client) GetListLayout(ctx context.Context, entity, layoutType string) ([]ListColumn, error) {
+	var columns []ListColumn
+	if err := c.getLayout(ctx, entity, layoutType, &columns); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// GetFieldListLayout returns a layout that is a list of fields: LayoutFilters or
+// LayoutMassUpdate.
+func (c *Client) GetFieldListLayout(ctx context.Context, entity, layoutType string) ([]string, error) {
+	var fields []string
+	if err := c.getLayout(ctx, entity, layoutType, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// getLayout fetches a layout and decodes it into v.
+func (c *Client) getLayout(ctx context.Context, entity, layoutType string, v any) error {
+	raw, err := c.GetLayout(ctx, entity, layoutType)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &EspoError{Message: "failed to parse " + layoutType + " layout", Cause: err}
+	}
+	return nil
+}
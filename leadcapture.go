@@ -0,0 +1,43 @@
+package espoclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// LeadCaptureClient submits web form payloads to an EspoCRM Lead Capture entry,
+// which creates or updates a lead (or a target list subscription) as configured in
+// EspoCRM. It authenticates with the entry's own API key only, so a website can
+// push leads without holding API user credentials.
+type LeadCaptureClient struct {
+	client *Client
+	apiKey string
+}
+
+// NewLeadCaptureClient creates a client for the Lead Capture entry with apiKey on
+// the instance at urlStr. Options other than authentication ones (timeouts, retry
+// policy, logging, ...) apply as for New.
+func NewLeadCaptureClient(urlStr, apiKey string, opts ...Option) (*LeadCaptureClient, error) {
+	if apiKey == "" {
+		return nil, &EspoError{Message: "lead capture API key is required"}
+	}
+	c, err := New(urlStr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &LeadCaptureClient{client: c, apiKey: apiKey}, nil
+}
+
+// Client returns the underlying client, e.g. to adjust its settings.
+func (l *LeadCaptureClient) Client() *Client {
+	return l.client
+}
+
+// Submit posts a form payload: a struct or map of the fields listed in the Lead
+// Capture entry, e.g. {"firstName": ..., "lastName": ..., "emailAddress": ...}.
+func (l *LeadCaptureClient) Submit(ctx context.Context, form any) error {
+	// The entry point accepts no credentials besides its key.
+	_, err := l.client.request(ctx, MethodPost, "LeadCapture/"+url.PathEscape(l.apiKey), form, nil,
+		WithAuthOverride(Credentials{}))
+	return err
+}
@@ -0,0 +1,163 @@
+package espoclient
+
+import "slices"
+
+// EspoCRM stores a relation as several flat attributes of the record rather than a
+// nested object: {link}Id and {link}Name for a link, {link}Ids and {link}Names for
+// a link-multiple, {link}Type, {link}Id and {link}Name for a link-parent. The types
+// below group them; read them from a record with LinkOf, LinkMultipleOf and
+// LinkParentOf, and write them back to a Payload with their Put methods.
+//
+// Typed records keep the flat attributes as fields, e.g. AccountID and
+// AccountName, so they decode from and encode to the API as is. Structs generated
+// by espogen have accessors for them returning these types:
+//
+//	account := opportunity.AccountLink() // espoclient.Link
+//	opportunity.SetAccountLink(espoclient.Link{ID: id})
+//
+// Hand-written structs can convert the same way, e.g. Link{ID: r.AccountID,
+// Name: r.AccountName}.
+
+// Link is the value of a link (belongsTo/hasOne) field.
+type Link struct {
+	ID   string
+	Name string
+}
+
+// LinkOf returns the link field of a record. A missing or null ID yields the zero Link.
+func LinkOf(record map[string]any, link string) Link {
+	id, _ := record[link+"Id"].(string)
+	name, _ := record[link+"Name"].(string)
+	return Link{ID: id, Name: name}
+}
+
+// IsZero reports whether the link is unset.
+func (l Link) IsZero() bool {
+	return l.ID == ""
+}
+
+// Put sets the link field on p; the zero Link clears it.
+func (l Link) Put(p Payload, link string) Payload {
+	if l.IsZero() {
+		return p.UnsetLink(link)
+	}
+	return p.SetLink(link, l.ID, l.Name)
+}
+
+// LinkMultiple is the value of a link-multiple (hasMany) field: the related IDs in
+// order and their names keyed by ID.
+type LinkMultiple struct {
+	IDs   []string
+	Names map[string]string
+}
+
+// LinkMultipleOf returns the link-multiple field of a record.
+func LinkMultipleOf(record map[string]any, link string) LinkMultiple {
+	var l LinkMultiple
+	switch ids := record[link+"Ids"].(type) {
+	case []string:
+		l.IDs = append(l.IDs, ids...)
+	case []any:
+		for _, id := range ids {
+			if s, ok := id.(string); ok {
+				l.IDs = append(l.IDs, s)
+			}
+		}
+	}
+	switch names := record[link+"Names"].(type) {
+	case map[string]string:
+		for id, name := range names {
+			l.setName(id, name)
+		}
+	case map[string]any:
+		for id, name := range names {
+			if s, ok := name.(string); ok {
+				l.setName(id, s)
+			}
+		}
+	}
+	return l
+}
+
+// Has reports whether id is related.
+func (l *LinkMultiple) Has(id string) bool {
+	return slices.Contains(l.IDs, id)
+}
+
+// Add relates id, unless it already is, with an optional name.
+func (l *LinkMultiple) Add(id, name string) {
+	if !l.Has(id) {
+		l.IDs = append(l.IDs, id)
+	}
+	if name != "" {
+		l.setName(id, name)
+	}
+}
+
+// Remove unrelates id and reports whether it was related.
+func (l *LinkMultiple) Remove(id string) bool {
+	i := slices.Index(l.IDs, id)
+	if i < 0 {
+		return false
+	}
+	l.IDs = slices.Delete(l.IDs, i, i+1)
+	delete(l.Names, id)
+	return true
+}
+
+// Name returns the name of a related record, or "" if it is not known.
+func (l *LinkMultiple) Name(id string) string {
+	return l.Names[id]
+}
+
+// Put sets the field on p. The IDs replace the stored ones, so Put a value read with
+// LinkMultipleOf and modified with Add and Remove to keep the other relations.
+func (l LinkMultiple) Put(p Payload, link string) Payload {
+	var names map[string]string
+	if len(l.Names) > 0 {
+		names = l.Names
+	}
+	return p.SetLinkMultiple(link, l.IDs, names)
+}
+
+func (l *LinkMultiple) setName(id, name string) {
+	if l.Names == nil {
+		l.Names = map[string]string{}
+	}
+	l.Names[id] = name
+}
+
+// LinkParent is the value of a link-parent field, such as the parent of a Task,
+// which can reference records of several entity types.
+type LinkParent struct {
+	Type string
+	ID   string
+	Name string
+}
+
+// LinkParentOf returns the link-parent field of a record.
+func LinkParentOf(record map[string]any, link string) LinkParent {
+	entityType, _ := record[link+"Type"].(string)
+	id, _ := record[link+"Id"].(string)
+	name, _ := record[link+"Name"].(string)
+	return LinkParent{Type: entityType, ID: id, Name: name}
+}
+
+// IsZero reports whether the link-parent is unset.
+func (l LinkParent) IsZero() bool {
+	return l.ID == ""
+}
+
+// Put sets the field on p; the zero LinkParent clears it.
+func (l LinkParent) Put(p Payload, link string) Payload {
+	if l.IsZero() {
+		p[link+"Type"] = nil
+		p[link+"Id"] = nil
+		return p
+	}
+	p.SetLinkParent(link, l.Type, l.ID)
+	if l.Name != "" {
+		p[link+"Name"] = l.Name
+	}
+	return p
+}
@@ -0,0 +1,211 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageSize is the page size used when walking collection endpoints.
+// EspoCRM caps maxSize at 200 by default.
+const defaultPageSize = 200
+
+// listResponse is the envelope EspoCRM returns from collection endpoints.
+// Records are kept raw so they can be decoded into the caller's type.
+type listResponse struct {
+	Total int               `json:"total"`
+	List  []json.RawMessage `json:"list"`
+}
+
+// listPage fetches a single page of a collection endpoint.
+func (c *Client) listPage(ctx context.Context, path string, query url.Values, offset, maxSize int) (*listResponse, error) {
+	q := url.Values{}
+	for key, vals := range query {
+		q[key] = append([]string(nil), vals...)
+	}
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("maxSize", strconv.Itoa(maxSize))
+
+	resp, err := c.request(ctx, MethodGet, path, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var page listResponse
+	if err := resp.GetParsedBody(&page); err != nil {
+		return nil, &EspoError{Message: "failed to parse list response", Cause: err}
+	}
+	return &page, nil
+}
+
+// listAll fetches every page of a collection endpoint and returns all records.
+func (c *Client) listAll(ctx context.Context, path string, params *SearchParams) ([]map[string]any, error) {
+	var records []map[string]any
+	it := c.newIterator(ctx, path, params)
+	for it.Next() {
+		records = append(records, it.Record())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Iterate returns an iterator over the records of an entity matching params,
+// following offset/maxSize pagination until the list or params.Limit is exhausted.
+func (c *Client) Iterate(ctx context.Context, entity string, params *SearchParams) *Iterator {
+	return c.newIterator(ctx, entity, params)
+}
+
+// ListAll returns all records of an entity matching params, up to params.Limit.
+// Prefer Iterate or ForEach for large lists.
+func (c *Client) ListAll(ctx context.Context, entity string, params *SearchParams) ([]map[string]any, error) {
+	return c.listAll(ctx, entity, params)
+}
+
+// Iterator walks the records of a collection endpoint, fetching pages on demand.
+//
+//	it := client.Iterate(ctx, "Contact", params)
+//	for it.Next() {
+//		contact := it.Record()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	client   *Client
+	ctx      context.Context
+	path     string
+	query    url.Values
+	pageSize int
+	limit    int // maximum number of records to fetch; 0 for all
+
+	page   []json.RawMessage
+	pos    int
+	offset int
+	total  int
+	done   bool
+	err    error
+}
+
+func (c *Client) newIterator(ctx context.Context, path string, params *SearchParams) *Iterator {
+	return &Iterator{
+		client:   c,
+		ctx:      ctx,
+		path:     path,
+		query:    params.Values(),
+		pageSize: params.pageSize(),
+		limit:    params.limit(),
+		total:    -1,
+		err:      params.Validate(),
+	}
+}
+
+// Next advances to the next record, fetching the next page when needed.
+// It returns false when the collection is exhausted or an error occurred.
+func (it *Iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.pos+1 < len(it.page) {
+		it.pos++
+		return true
+	}
+	return it.fetch()
+}
+
+// NextPage advances to the next page, for processing records in batches with Page
+// or DecodePage. It returns false when the collection is exhausted or an error
+// occurred. Records of the current page not visited with Next are skipped.
+func (it *Iterator) NextPage() bool {
+	if it.err != nil {
+		return false
+	}
+	return it.fetch()
+}
+
+// fetch loads the page following the records fetched so far.
+func (it *Iterator) fetch() bool {
+	if it.done {
+		it.page = nil
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	size := it.pageSize
+	if it.limit > 0 {
+		size = min(size, it.limit-it.offset)
+	}
+	page, err := it.client.listPage(it.ctx, it.path, it.query, it.offset, size)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = page.List
+	it.pos = 0
+	it.offset += len(page.List)
+	it.total = page.Total
+
+	// A negative total means EspoCRM did not count the records; rely on a short page instead.
+	if len(page.List) < size || (page.Total >= 0 && it.offset >= page.Total) ||
+		(it.limit > 0 && it.offset >= it.limit) {
+		it.done = true
+	}
+	return len(it.page) > 0
+}
+
+// Page returns the records of the current page as maps.
+func (it *Iterator) Page() []map[string]any {
+	var records []map[string]any
+	if err := it.DecodePage(&records); err != nil {
+		return nil
+	}
+	return records
+}
+
+// DecodePage unmarshals the records of the current page into v, a pointer to a slice.
+func (it *Iterator) DecodePage(v any) error {
+	data, err := json.Marshal(it.page)
+	if err != nil {
+		return &EspoError{Message: "failed to decode page", Cause: err}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return &EspoError{Message: "failed to decode page", Cause: err}
+	}
+	return nil
+}
+
+// Record returns the current record as a map.
+func (it *Iterator) Record() map[string]any {
+	var record map[string]any
+	if err := it.Decode(&record); err != nil {
+		return nil
+	}
+	return record
+}
+
+// Decode unmarshals the current record into v.
+func (it *Iterator) Decode(v any) error {
+	if it.pos >= len(it.page) {
+		return &EspoError{Message: "iterator has no current record"}
+	}
+	if err := json.Unmarshal(it.page[it.pos], v); err != nil {
+		return &EspoError{Message: "failed to decode record", Cause: err}
+	}
+	return nil
+}
+
+// Total returns the total reported by the server, or -1 if unknown or not fetched yet.
+func (it *Iterator) Total() int {
+	return it.total
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
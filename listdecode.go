@@ -0,0 +1,112 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DecodeList calls fn with each record of a collection endpoint matching params,
+// decoded into T. Unlike ListAs, records are decoded one at a time as the response
+// is read, so memory stays bounded by one record however large the pages are.
+// Pages are fetched up to params.Limit; an error returned by fn stops the listing
+// and is returned.
+//
+//	err := espoclient.DecodeList(ctx, client, "Lead", params, func(lead Lead) error {
+//		return w.Write(lead)
+//	})
+func DecodeList[T any](ctx context.Context, c *Client, path string, params *SearchParams, fn func(T) error) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	query, size, limit := params.Values(), params.pageSize(), params.limit()
+	for offset := 0; ; {
+		if limit > 0 {
+			size = min(size, limit-offset)
+		}
+		q := url.Values{}
+		for key, vals := range query {
+			q[key] = vals
+		}
+		q.Set("offset", strconv.Itoa(offset))
+		q.Set("maxSize", strconv.Itoa(size))
+
+		count, total, err := decodeListPage(ctx, c, path, q, fn)
+		if err != nil {
+			return err
+		}
+		offset += count
+		// A negative total means EspoCRM did not count the records; rely on a short page instead.
+		if count < size || (total >= 0 && offset >= total) || (limit > 0 && offset >= limit) {
+			return nil
+		}
+	}
+}
+
+// decodeListPage requests one page and streams its records to fn. It returns the
+// number of records and the total reported by the server.
+func decodeListPage[T any](ctx context.Context, c *Client, path string, q url.Values, fn func(T) error) (count, total int, err error) {
+	resp, err := c.RequestStream(ctx, MethodGet, path, q, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+
+	fail := func(err error) (int, int, error) {
+		return count, total, &EspoError{Message: "failed to decode list response", Cause: err}
+	}
+	dec := json.NewDecoder(resp.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return fail(err)
+	}
+	total = -1
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		switch key {
+		case "total":
+			if err := dec.Decode(&total); err != nil {
+				return fail(err)
+			}
+		case "list":
+			if err := expectDelim(dec, '['); err != nil {
+				return fail(err)
+			}
+			for dec.More() {
+				var record T
+				if err := dec.Decode(&record); err != nil {
+					return fail(err)
+				}
+				count++
+				if err := fn(record); err != nil {
+					return count, total, err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return fail(err)
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return fail(err)
+			}
+		}
+	}
+	return count, total, nil
+}
+
+// expectDelim reads the next token and checks that it is delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+	return nil
+}
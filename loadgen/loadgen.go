@@ -0,0 +1,251 @@
+// Package loadgen generates API load against sandbox EspoCRM instances to validate
+// instance sizing. It refuses to run against hosts that do not look like
+// non-production environments.
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// ErrProductionTarget is returned by Run when the client points at a host that is
+// not recognized as a non-production environment.
+var ErrProductionTarget = errors.New("loadgen: refusing to generate load against a host that may be production")
+
+const (
+	defaultMaxInFlight = 64
+	tickInterval       = 10 * time.Millisecond
+)
+
+// Scenario is one kind of traffic in the mix, e.g. "list leads" or "create contact".
+type Scenario struct {
+	Name   string
+	Weight int // relative frequency within the mix; 1 if zero
+	Run    func(ctx context.Context, c *espoclient.Client) error
+}
+
+// Config describes a load test run.
+type Config struct {
+	Scenarios []Scenario
+
+	StartRPS  float64       // scenario executions per second at the beginning of the ramp
+	TargetRPS float64       // rate reached at the end of the ramp and held afterwards
+	RampUp    time.Duration // linear ramp from StartRPS to TargetRPS
+	Duration  time.Duration // total length of the run, including the ramp
+
+	// MaxInFlight caps concurrent scenario executions; executions due while the cap
+	// is reached are counted as dropped. defaultMaxInFlight if zero.
+	MaxInFlight int
+
+	// AllowHosts lists additional hostnames or IP addresses that are known to be
+	// non-production, e.g. a test instance on a private address.
+	AllowHosts []string
+}
+
+// Stats summarizes the executions of one scenario.
+type Stats struct {
+	Count  int
+	Errors int
+	Mean   time.Duration
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+
+	// LastError is the most recent error returned by the scenario.
+	LastError error
+
+	latencies []time.Duration
+}
+
+// Report is the outcome of a run.
+type Report struct {
+	Started   time.Time
+	Finished  time.Time
+	Count     int
+	Errors    int
+	Dropped   int
+	Scenarios map[string]*Stats
+}
+
+// Run executes the scenario mix against the client's instance following the
+// configured rate ramp, and returns latency and error statistics. It stops early
+// when ctx is cancelled; the report then covers the executions made so far.
+func Run(ctx context.Context, c *espoclient.Client, cfg Config) (*Report, error) {
+	if !IsNonProduction(c.BaseURL(), cfg.AllowHosts...) {
+		return nil, fmt.Errorf("%w: %s", ErrProductionTarget, c.BaseURL().Host)
+	}
+	if len(cfg.Scenarios) == 0 {
+		return nil, errors.New("loadgen: no scenarios configured")
+	}
+	if cfg.Duration <= 0 || cfg.TargetRPS <= 0 {
+		return nil, errors.New("loadgen: Duration and TargetRPS must be positive")
+	}
+	maxInFlight := cfg.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = defaultMaxInFlight
+	}
+
+	report := &Report{Started: time.Now(), Scenarios: map[string]*Stats{}}
+	for _, s := range cfg.Scenarios {
+		report.Scenarios[s.Name] = &Stats{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		inFlight = make(chan struct{}, maxInFlight)
+		ticker   = time.NewTicker(tickInterval)
+		last     = report.Started
+		credit   float64
+	)
+	defer ticker.Stop()
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case now := <-ticker.C:
+			credit += cfg.rate(now.Sub(report.Started)) * now.Sub(last).Seconds()
+			last = now
+			for ; credit >= 1; credit-- {
+				scenario := pick(cfg.Scenarios)
+				select {
+				case inFlight <- struct{}{}:
+				default:
+					mu.Lock()
+					report.Dropped++
+					mu.Unlock()
+					continue
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-inFlight }()
+					start := time.Now()
+					err := scenario.Run(ctx, c)
+					elapsed := time.Since(start)
+
+					mu.Lock()
+					defer mu.Unlock()
+					stats := report.Scenarios[scenario.Name]
+					stats.Count++
+					stats.latencies = append(stats.latencies, elapsed)
+					if err != nil {
+						stats.Errors++
+						stats.LastError = err
+					}
+				}()
+			}
+		}
+	}
+	wg.Wait()
+
+	report.Finished = time.Now()
+	for _, stats := range report.Scenarios {
+		stats.summarize()
+		report.Count += stats.Count
+		report.Errors += stats.Errors
+	}
+	return report, nil
+}
+
+// rate returns the target executions per second at the given point of the run.
+func (cfg Config) rate(elapsed time.Duration) float64 {
+	if cfg.RampUp <= 0 || elapsed >= cfg.RampUp {
+		return cfg.TargetRPS
+	}
+	progress := float64(elapsed) / float64(cfg.RampUp)
+	return cfg.StartRPS + (cfg.TargetRPS-cfg.StartRPS)*progress
+}
+
+// pick chooses a scenario at random according to the weights.
+func pick(scenarios []Scenario) Scenario {
+	total := 0
+	for _, s := range scenarios {
+		total += max(s.Weight, 1)
+	}
+	n := rand.IntN(total)
+	for _, s := range scenarios {
+		if n -= max(s.Weight, 1); n < 0 {
+			return s
+		}
+	}
+	return scenarios[len(scenarios)-1]
+}
+
+// summarize computes the latency statistics.
+func (s *Stats) summarize() {
+	if len(s.latencies) == 0 {
+		return
+	}
+	slices.Sort(s.latencies)
+	var sum time.Duration
+	for _, l := range s.latencies {
+		sum += l
+	}
+	s.Mean = sum / time.Duration(len(s.latencies))
+	s.P50 = percentile(s.latencies, 0.50)
+	s.P90 = percentile(s.latencies, 0.90)
+	s.P99 = percentile(s.latencies, 0.99)
+	s.Max = s.latencies[len(s.latencies)-1]
+	s.latencies = nil
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
+
+// nonProductionMarkers mark sandbox environments when used as a hostname label,
+// alone or combined with a hyphen or number ("staging", "crm-dev", "qa2").
+var nonProductionMarkers = []string{"sandbox", "staging", "stage", "dev", "test", "qa", "uat", "demo"}
+
+// IsNonProduction reports whether u points at a host that looks like a
+// non-production environment: localhost and loopback addresses, the .test and
+// .localhost domains reserved for testing, hostnames with a label such as
+// "staging", "crm-dev" or "qa2", and any of the explicitly allowed hosts.
+//
+// Private addresses and hosts of internal domains (.internal, .local, .lan, ...)
+// are not considered safe by themselves, as on-premises production instances
+// live there too: they need a marker label or an entry in allowHosts.
+func IsNonProduction(u *url.URL, allowHosts ...string) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range allowHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	if host == "localhost" {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback()
+	}
+	for _, suffix := range []string{".test", ".localhost"} {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	for _, label := range strings.Split(host, ".") {
+		for _, part := range strings.Split(label, "-") {
+			if slices.Contains(nonProductionMarkers, strings.TrimRight(part, "0123456789")) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package loadgen
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestIsNonProduction(t *testing.T) {
+	tests := []struct {
+		url   string
+		allow []string
+		want  bool
+	}{
+		{"http://localhost:8080", nil, true},
+		{"http://127.0.0.1", nil, true},
+		{"http://[::1]", nil, true},
+		{"https://crm.test", nil, true},
+		{"https://crm-staging.example.com", nil, true},
+		{"https://qa2.crm.example.com", nil, true},
+		{"https://dev.corp.internal", nil, true},
+		{"https://crm.example.com", nil, false},
+		{"https://crm.corp.internal", nil, false},
+		{"https://crm.local", nil, false},
+		{"http://10.0.0.5", nil, false},
+		{"http://192.168.1.20", nil, false},
+		{"http://10.0.0.5", []string{"10.0.0.5"}, true},
+		{"https://crm.corp.internal", []string{"CRM.corp.internal"}, true},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := IsNonProduction(u, tt.allow...); got != tt.want {
+			t.Errorf("IsNonProduction(%s, %v) = %v, want %v", tt.url, tt.allow, got, tt.want)
+		}
+	}
+}
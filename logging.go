@@ -0,0 +1,106 @@
+package espoclient
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// sensitiveHeaders are logged as "[REDACTED]".
+var sensitiveHeaders = map[string]bool{
+	"Authorization":        true,
+	"Espo-Authorization":   true,
+	"X-Api-Key":            true,
+	"X-Hmac-Authorization": true,
+	"X-Auth-Token":         true,
+	"Cookie":               true,
+	"Set-Cookie":           true,
+}
+
+// clientLogger logs the requests sent by a client.
+type clientLogger struct {
+	logger  *slog.Logger
+	success slog.Level
+	failure slog.Level
+}
+
+// SetLogger logs every request attempt to logger with its method, path, status,
+// duration and headers; credentials in the headers are redacted. Successful requests
+// are logged at debug level and failed ones at warn level, see SetLogLevels.
+// Passing nil disables logging.
+func (c *Client) SetLogger(logger *slog.Logger) *Client {
+	if logger == nil {
+		c.logger = nil
+		return c
+	}
+	if c.logger == nil {
+		c.logger = &clientLogger{success: slog.LevelDebug, failure: slog.LevelWarn}
+	}
+	c.logger.logger = logger
+	return c
+}
+
+// SetLogLevels sets the levels at which successful and failed requests are logged.
+// It has no effect until a logger is set.
+func (c *Client) SetLogLevels(success, failure slog.Level) *Client {
+	if c.logger != nil {
+		c.logger.success, c.logger.failure = success, failure
+	}
+	return c
+}
+
+// logRequest logs one attempt of req that started at start.
+func (c *Client) logRequest(req *http.Request, attempt int, start time.Time, resp *Response, err error) {
+	l := c.logger
+	if l == nil {
+		return
+	}
+	ctx := req.Context()
+	level := l.success
+	if err != nil {
+		level = l.failure
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", c.clock.Now().Sub(start)),
+		slog.Any("headers", loggedHeaders(req.Header)),
+	}
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		resp = respErr.Response
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.logger.LogAttrs(context.WithoutCancel(ctx), level, "espo request", attrs...)
+}
+
+// loggedHeaders formats request headers for logs, redacting credentials.
+type loggedHeaders http.Header
+
+// LogValue implements slog.LogValuer.
+func (h loggedHeaders) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(h))
+	for name, values := range h {
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = "[REDACTED]"
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.GroupValue(attrs...)
+}
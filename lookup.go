@@ -0,0 +1,62 @@
+package espoclient
+
+import "context"
+
+// FindByEmailAddress returns all records of the given entity type that have
+// the email address as their primary or any secondary address.
+//
+// A plain where-equals on the emailAddress attribute only matches the primary
+// address, so the lookup goes through the emailAddresses link instead and
+// compares against the lower-cased address EspoCRM stores there.
+func (c *Client) FindByEmailAddress(ctx context.Context, entity, email string) ([]map[string]any, error) {
+	email = NormalizeEmail(email)
+	if email == "" {
+		return nil, &EspoError{Message: "email address is empty"}
+	}
+	return c.listAll(ctx, entity, linkEquals("emailAddresses.lower", email))
+}
+
+// FindByPhoneNumber returns all records of the given entity type that have
+// the phone number as their primary or any secondary number.
+//
+// Numbers are matched on their digits only (the "numeric" attribute of the
+// phoneNumbers link), so "+1 (555) 010-0000" and "15550100000" are equivalent.
+// If a Normalizer is set, national numbers get its default country code first.
+func (c *Client) FindByPhoneNumber(ctx context.Context, entity, phone string) ([]map[string]any, error) {
+	if c.normalizer != nil {
+		phone = c.normalizer.phone(phone)
+	}
+	digits := onlyDigits(phone)
+	if digits == "" {
+		return nil, &EspoError{Message: "phone number contains no digits"}
+	}
+	return c.listAll(ctx, entity, linkEquals("phoneNumbers.numeric", digits))
+}
+
+// matchCondition returns the condition matching records whose field equals value.
+// Email addresses and phone numbers are matched as FindByEmailAddress and
+// FindByPhoneNumber do, on secondary values too.
+func (c *Client) matchCondition(field string, value any) WhereItem {
+	s, _ := value.(string)
+	switch field {
+	case "emailAddress":
+		if email := NormalizeEmail(s); email != "" {
+			return Equals("emailAddresses.lower", email)
+		}
+	case "phoneNumber":
+		if c.normalizer != nil {
+			s = c.normalizer.phone(s)
+		}
+		if digits := onlyDigits(s); digits != "" {
+			return Equals("phoneNumbers.numeric", digits)
+		}
+	}
+	return Equals(field, value)
+}
+
+// linkEquals builds search params with a single equals condition on a link attribute.
+func linkEquals(attribute, value string) *SearchParams {
+	return &SearchParams{
+		Where: []WhereItem{{Type: "equals", Attribute: attribute, Value: value}},
+	}
+}
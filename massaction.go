@@ -0,0 +1,104 @@
+package espoclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// MassSelection selects the records a mass action applies to: explicit IDs, every
+// record matching the where clause and filters of Params, or every record if All
+// is set. A
+// selection with none of them, or with a non-nil but empty IDs, is rejected
+// rather than applied to the whole table.
+type MassSelection struct {
+	IDs    []string
+	Params *SearchParams // used when IDs is nil
+	All    bool          // selects all records matching Params, or all records if Params is nil
+	// Background asks the server to process the action as a job, whose ID is
+	// returned in MassActionResult.JobID.
+	Background bool
+}
+
+// MassActionResult is the outcome of a mass action.
+type MassActionResult struct {
+	Count int      `json:"count"`
+	IDs   []string `json:"ids,omitempty"`
+	// JobID identifies the background job when the server did not process the action
+	// immediately; see MassActionStatus.
+	JobID string `json:"id,omitempty"`
+}
+
+// MassUpdate sets the attributes in data on all selected records.
+func (c *Client) MassUpdate(ctx context.Context, entity string, selection MassSelection, data map[string]any) (*MassActionResult, error) {
+	return c.massAction(ctx, entity, "update", selection, data)
+}
+
+// MassDelete deletes all selected records.
+func (c *Client) MassDelete(ctx context.Context, entity string, selection MassSelection) (*MassActionResult, error) {
+	return c.massAction(ctx, entity, "delete", selection, nil)
+}
+
+// MassRecalculate re-runs the before-save formula of all selected records.
+func (c *Client) MassRecalculate(ctx context.Context, entity string, selection MassSelection) (*MassActionResult, error) {
+	return c.massAction(ctx, entity, "recalculateFormula", selection, nil)
+}
+
+// MassActionStatus returns the status of a background mass action job
+// ("Pending", "Running", "Success" or "Failed").
+func (c *Client) MassActionStatus(ctx context.Context, jobID string) (string, error) {
+	status, err := c.getObject(ctx, "MassAction/"+url.PathEscape(jobID)+"/status", nil)
+	if err != nil {
+		return "", err
+	}
+	s, _ := status["status"].(string)
+	return s, nil
+}
+
+// massAction posts an action to the MassAction endpoint.
+func (c *Client) massAction(ctx context.Context, entity, action string, selection MassSelection, data map[string]any) (*MassActionResult, error) {
+	params := map[string]any{}
+	switch {
+	case selection.IDs != nil:
+		if len(selection.IDs) == 0 {
+			return nil, &EspoError{Message: "mass action selection has an empty list of IDs"}
+		}
+		params["ids"] = selection.IDs
+	case selection.Params != nil && (len(selection.Params.Where) > 0 || selection.Params.hasFilters()):
+		params["where"] = []WhereItem{}
+		if selection.Params.Where != nil {
+			params["where"] = selection.Params.Where
+		}
+		if selection.Params.hasFilters() {
+			searchParams := map[string]any{}
+			selection.Params.addFilters(searchParams)
+			params["searchParams"] = searchParams
+		}
+	case selection.All:
+		params["where"] = []WhereItem{}
+	default:
+		return nil, &EspoError{Message: "mass action selection has no IDs, where clause or filter; set All to select every record"}
+	}
+
+	body := map[string]any{
+		"entityType": entity,
+		"action":     action,
+		"params":     params,
+		"data":       map[string]any{},
+	}
+	if data != nil {
+		body["data"] = data
+	}
+	if selection.Background {
+		body["idle"] = true
+	}
+
+	resp, err := c.request(ctx, MethodPost, "MassAction", body, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result MassActionResult
+	if err := resp.GetParsedBody(&result); err != nil {
+		return nil, &EspoError{Message: "failed to parse mass action result", Cause: err}
+	}
+	return &result, nil
+}
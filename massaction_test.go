@@ -0,0 +1,60 @@
+package espoclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestMassDeleteSelection(t *testing.T) {
+	var params json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Params json.RawMessage }
+		json.NewDecoder(r.Body).Decode(&body)
+		params = body.Params
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":1}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	open := []espoclient.WhereItem{{Type: "equals", Attribute: "status", Value: "New"}}
+	tests := []struct {
+		name      string
+		selection espoclient.MassSelection
+		want      string // params sent; empty if the selection is rejected
+	}{
+		{"ids", espoclient.MassSelection{IDs: []string{"1"}}, `{"ids":["1"]}`},
+		{"where", espoclient.MassSelection{Params: &espoclient.SearchParams{Where: open}}, `{"where":[{"type":"equals","attribute":"status","value":"New"}]}`},
+		{"filters", espoclient.MassSelection{Params: &espoclient.SearchParams{PrimaryFilter: "open", BoolFilters: []string{espoclient.BoolFilterOnlyMy}}}, `{"searchParams":{"boolFilterList":["onlyMy"],"primaryFilter":"open"},"where":[]}`},
+		{"all", espoclient.MassSelection{All: true}, `{"where":[]}`},
+		{"empty ids", espoclient.MassSelection{IDs: []string{}, Params: &espoclient.SearchParams{Where: open}}, ""},
+		{"nothing", espoclient.MassSelection{}, ""},
+		{"empty where", espoclient.MassSelection{Params: &espoclient.SearchParams{}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params = nil
+			_, err := client.MassDelete(context.Background(), "Lead", tt.selection)
+			if tt.want == "" {
+				if err == nil || params != nil {
+					t.Errorf("selection was sent as %s, want it rejected", params)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(params) != tt.want {
+				t.Errorf("params = %s, want %s", params, tt.want)
+			}
+		})
+	}
+}
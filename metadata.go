@@ -0,0 +1,149 @@
+package espoclient
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// defaultMetadataTTL is how long cached metadata is served before it is refreshed.
+const defaultMetadataTTL = 10 * time.Minute
+
+// GetMetadata fetches the application metadata (entityDefs, scopes, clientDefs, ...),
+// bypassing the cache. Most callers should use Metadata instead.
+func (c *Client) GetMetadata(ctx context.Context) (map[string]any, error) {
+	return c.getObject(ctx, "Metadata", nil)
+}
+
+// Metadata returns the application metadata from the client's cache, fetching it
+// on first use. Once the TTL has passed the cached copy is still returned while a
+// refresh runs in the background. The returned map is shared and must not be modified.
+// Call InvalidateMetadata after changing entities or fields on the server.
+func (c *Client) Metadata(ctx context.Context) (map[string]any, error) {
+	return c.metadata.get(ctx, c.clock, c.GetMetadata)
+}
+
+// InvalidateMetadata drops the cached metadata so the next Metadata call fetches it again.
+func (c *Client) InvalidateMetadata() {
+	c.metadata.invalidate()
+}
+
+// SetMetadataTTL sets how long cached metadata is considered fresh.
+// A TTL of zero or less disables caching.
+func (c *Client) SetMetadataTTL(ttl time.Duration) *Client {
+	c.metadata.mu.Lock()
+	c.metadata.ttl = ttl
+	c.metadata.mu.Unlock()
+	return c
+}
+
+// GetI18n fetches the translation tree for a language (e.g. "de_DE").
+// An empty language returns the authenticated user's language.
+func (c *Client) GetI18n(ctx context.Context, language string) (map[string]any, error) {
+	var query url.Values
+	if language != "" {
+		query = url.Values{"language": {language}}
+	}
+	return c.getObject(ctx, "I18n", query)
+}
+
+// getObject performs a GET and decodes the JSON object in the response body.
+func (c *Client) getObject(ctx context.Context, path string, query url.Values) (map[string]any, error) {
+	var data any
+	if query != nil {
+		data = query
+	}
+	resp, err := c.request(ctx, MethodGet, path, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]any
+	if err := resp.GetParsedBody(&obj); err != nil {
+		return nil, &EspoError{Message: "failed to parse " + path + " response", Cause: err}
+	}
+	return obj, nil
+}
+
+// lookupPath walks nested JSON objects by key and returns the value found, or nil.
+func lookupPath(m map[string]any, keys ...string) any {
+	var cur any = m
+	for _, key := range keys {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = obj[key]
+	}
+	return cur
+}
+
+// metadataCache is a concurrency-safe, TTL-based cache of the metadata tree.
+type metadataCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	data       map[string]any
+	fetchedAt  time.Time
+	generation int // incremented on invalidation so in-flight fetches don't store stale data
+	refreshing bool
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{ttl: defaultMetadataTTL}
+}
+
+func (m *metadataCache) get(ctx context.Context, clock Clock, fetch func(context.Context) (map[string]any, error)) (map[string]any, error) {
+	m.mu.Lock()
+	if m.ttl <= 0 {
+		m.mu.Unlock()
+		return fetch(ctx)
+	}
+	if m.data != nil {
+		data := m.data
+		if clock.Now().Sub(m.fetchedAt) >= m.ttl && !m.refreshing {
+			m.refreshing = true
+			go m.refresh(context.WithoutCancel(ctx), clock, fetch, m.generation)
+		}
+		m.mu.Unlock()
+		return data, nil
+	}
+	generation := m.generation
+	m.mu.Unlock()
+
+	data, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.store(data, clock.Now(), generation)
+	return data, nil
+}
+
+// refresh fetches metadata in the background. On failure the stale copy is kept
+// and the next call past the TTL tries again.
+func (m *metadataCache) refresh(ctx context.Context, clock Clock, fetch func(context.Context) (map[string]any, error), generation int) {
+	data, err := fetch(ctx)
+
+	m.mu.Lock()
+	m.refreshing = false
+	m.mu.Unlock()
+	if err == nil {
+		m.store(data, clock.Now(), generation)
+	}
+}
+
+func (m *metadataCache) store(data map[string]any, fetchedAt time.Time, generation int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if generation != m.generation {
+		return
+	}
+	m.data = data
+	m.fetchedAt = fetchedAt
+}
+
+func (m *metadataCache) invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data = nil
+	m.generation++
+}
@@ -0,0 +1,130 @@
+package espoclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// NormalizeEmail trims surrounding whitespace and lower-cases an email address.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone formats a phone number as E.164 ("+" followed by up to 15 digits).
+// Numbers written with a leading "+" or "00" are treated as international.
+// Other numbers are treated as national: a single leading trunk "0" is dropped
+// and defaultCountryCode (e.g. "1", "44", "380") is prepended.
+func NormalizePhone(phone, defaultCountryCode string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	international := strings.HasPrefix(phone, "+")
+	digits := onlyDigits(phone)
+
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+	if !international {
+		cc := onlyDigits(defaultCountryCode)
+		if cc == "" {
+			return "", fmt.Errorf("phone number %q has no country code and no default is configured", phone)
+		}
+		digits = cc + strings.TrimPrefix(digits, "0")
+	}
+
+	if len(digits) < 8 || len(digits) > 15 {
+		return "", fmt.Errorf("phone number %q is not a valid E.164 number", phone)
+	}
+	return "+" + digits, nil
+}
+
+// onlyDigits strips every non-digit character from s.
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// Normalizer cleans up email addresses and phone numbers in record payloads,
+// reducing duplicates caused by formatting differences.
+type Normalizer struct {
+	// DefaultCountryCode is used for phone numbers without an international prefix.
+	// If empty, such numbers are left untouched.
+	DefaultCountryCode string
+}
+
+// NormalizeRecord normalizes the emailAddress, emailAddressData, phoneNumber and
+// phoneNumberData attributes of record in place. Phone numbers that cannot be
+// converted to E.164 are kept as they are.
+func (n Normalizer) NormalizeRecord(record map[string]any) {
+	if v, ok := record["emailAddress"].(string); ok {
+		record["emailAddress"] = NormalizeEmail(v)
+	}
+	if v, ok := record["phoneNumber"].(string); ok {
+		record["phoneNumber"] = n.phone(v)
+	}
+	if items, ok := record["emailAddressData"].([]any); ok {
+		record["emailAddressData"] = normalizeDataItems(items, "emailAddress", NormalizeEmail)
+	}
+	if items, ok := record["phoneNumberData"].([]any); ok {
+		record["phoneNumberData"] = normalizeDataItems(items, "phoneNumber", n.phone)
+	}
+}
+
+// Hook returns a RecordHook applying NormalizeRecord to create and update payloads.
+func (n Normalizer) Hook() RecordHook {
+	return func(_ context.Context, _ Operation, _ string, record map[string]any) error {
+		n.NormalizeRecord(record)
+		return nil
+	}
+}
+
+func (n Normalizer) phone(v string) string {
+	normalized, err := NormalizePhone(v, n.DefaultCountryCode)
+	if err != nil {
+		return v
+	}
+	return normalized
+}
+
+// normalizeDataItems returns a copy of an emailAddressData/phoneNumberData list
+// with the given key normalized. The original items are not modified.
+func normalizeDataItems(items []any, key string, normalize func(string) string) []any {
+	out := make([]any, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			out[i] = item
+			continue
+		}
+		copied := make(map[string]any, len(m))
+		for k, v := range m {
+			copied[k] = v
+		}
+		if v, ok := copied[key].(string); ok {
+			copied[key] = normalize(v)
+		}
+		out[i] = copied
+	}
+	return out
+}
+
+// SetNormalizer enables email and phone normalization on create and update
+// payloads, and in FindByEmailAddress/FindByPhoneNumber.
+func (c *Client) SetNormalizer(n Normalizer) *Client {
+	if c.normalizer == nil {
+		c.normalizerHook = len(c.recordHooks)
+		c.AddRecordHook(n.Hook())
+	} else {
+		// The hooks may be shared with a client this one was derived from, or
+		// one derived from it; replace the hook in a copy.
+		c.recordHooks = slices.Clone(c.recordHooks)
+		c.recordHooks[c.normalizerHook] = n.Hook()
+	}
+	c.normalizer = &n
+	return c
+}
@@ -0,0 +1,47 @@
+package espoclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// Clients derived with WithHeaders or WithAuth keep their own normalizer.
+func TestSetNormalizerOnDerivedClient(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+	base, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base.SetNormalizer(espoclient.Normalizer{DefaultCountryCode: "1"})
+	uk := base.WithHeaders(map[string]string{"X-Tenant": "uk"})
+	uk.SetNormalizer(espoclient.Normalizer{DefaultCountryCode: "44"})
+	base.SetNormalizer(espoclient.Normalizer{DefaultCountryCode: "380"})
+
+	tests := []struct {
+		client *espoclient.Client
+		want   string
+	}{
+		{base, `{"phoneNumber":"+380201234567"}`},
+		{uk, `{"phoneNumber":"+44201234567"}`},
+	}
+	for _, tt := range tests {
+		if _, err := tt.client.CreateEntity(context.Background(), "Lead", map[string]any{"phoneNumber": "0201234567"}); err != nil {
+			t.Fatal(err)
+		}
+		if body != tt.want {
+			t.Errorf("sent %s, want %s", body, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package espoclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// RequestOption customizes a single request.
+type RequestOption func(*requestOptions)
+
+// requestOptions is the resolved set of per-request settings.
+type requestOptions struct {
+	retryNonIdempotent bool
+	idempotencyKey     string
+	headers            http.Header
+	query              url.Values
+	credentials        *Credentials
+	timeout            time.Duration
+	deadline           time.Time
+	output             io.Writer
+	stream             *streamTarget
+	selectAttrs        []string
+	links              []string
+	stringToSign       string // set by the client for HMAC-signed requests
+	noCache            bool
+	versionNumber      *int
+	ifUnmodifiedSince  string
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// RetryNonIdempotent allows the retry policy to retry this request even if its
+// method is not listed as idempotent (typically POST). Only use it when a duplicate
+// is acceptable or the server deduplicates, e.g. together with WithIdempotencyKey.
+func RetryNonIdempotent(allow bool) RequestOption {
+	return func(o *requestOptions) {
+		o.retryNonIdempotent = allow
+	}
+}
+
+// WithIdempotencyKey sends the key in the Idempotency-Key header so that a proxy or
+// server-side extension can recognize retried requests.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithHeader sets a header on the request, overriding the headers map and any
+// header the client sets itself.
+func WithHeader(name, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.headers == nil {
+			o.headers = http.Header{}
+		}
+		o.headers.Set(name, value)
+	}
+}
+
+// WithQuery adds a query parameter to the request URL, for any method.
+func WithQuery(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.query == nil {
+			o.query = url.Values{}
+		}
+		o.query.Add(key, value)
+	}
+}
+
+// WithAuthOverride sends the request with the given credentials instead of the
+// client's, so one client can act on behalf of several API users.
+func WithAuthOverride(cred Credentials) RequestOption {
+	return func(o *requestOptions) {
+		o.credentials = &cred
+	}
+}
+
+// WithTimeout bounds the request, including retries and reading the response,
+// by the given duration. It replaces the client-wide per-attempt timeout, so quick
+// health checks and long exports can share one client.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithDeadline is like WithTimeout, with the request bounded by an absolute time.
+func WithDeadline(deadline time.Time) RequestOption {
+	return func(o *requestOptions) {
+		o.deadline = deadline
+	}
+}
+
+// overridesTimeout reports whether the client-wide timeout is replaced: by the
+// request's own time limit, or because a streamed body is read after returning.
+func (o *requestOptions) overridesTimeout() bool {
+	return o.timeout > 0 || !o.deadline.IsZero() || o.stream != nil
+}
+
+// WithOutput streams a successful response body to w as it arrives instead of
+// buffering it in Response.Body, which is left empty. Error responses are still buffered.
+func WithOutput(w io.Writer) RequestOption {
+	return func(o *requestOptions) {
+		o.output = w
+	}
+}
+
+type requestOptionsKey struct{}
+
+// ContextWithRequestOptions returns a context carrying options that apply to every
+// request made with it, including those made by higher-level helpers. Options passed
+// to a call directly are applied after (and so override) the context's options.
+func ContextWithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	// The stored slice is never appended to in place: requests sharing ctx may run
+	// concurrently, so each combines the options into a new slice.
+	combined := slices.Concat(requestOptionsFromContext(ctx), opts)
+	return context.WithValue(ctx, requestOptionsKey{}, slices.Clip(combined))
+}
+
+func requestOptionsFromContext(ctx context.Context) []RequestOption {
+	opts, _ := ctx.Value(requestOptionsKey{}).([]RequestOption)
+	return opts
+}
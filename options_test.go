@@ -0,0 +1,60 @@
+package espoclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// Requests sharing a context must not see each other's per-call options, even
+// when the context's options slice has spare capacity.
+func TestContextRequestOptionsConcurrent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"call":%q}`, r.Header.Get("X-Call"))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var base []espoclient.RequestOption
+	for i := range 5 {
+		base = append(base, espoclient.WithHeader(fmt.Sprintf("X-Base-%d", i), "1"))
+	}
+	ctx := espoclient.ContextWithRequestOptions(context.Background(), base...)
+	nested := espoclient.ContextWithRequestOptions(ctx, espoclient.WithHeader("X-Nested", "1"))
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		ctx := ctx
+		if i%2 == 1 {
+			ctx = nested
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			want := fmt.Sprint(i)
+			resp, err := client.RequestWithContext(ctx, espoclient.MethodGet, "Test", nil, nil, espoclient.WithHeader("X-Call", want))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			var body struct{ Call string }
+			if err := resp.GetParsedBody(&body); err != nil {
+				t.Error(err)
+				return
+			}
+			if body.Call != want {
+				t.Errorf("request %s was sent with the options of request %s", want, body.Call)
+			}
+		}()
+	}
+	wg.Wait()
+}
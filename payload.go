@@ -0,0 +1,69 @@
+package espoclient
+
+// Payload is a record payload for create and update requests.
+// It is a plain map, so it can be passed anywhere a map[string]any is accepted.
+//
+//	p := espoclient.Payload{"name": "Acme"}.
+//		SetLink("assignedUser", userID, "").
+//		SetLinkMultiple("teams", []string{teamID}, nil)
+type Payload map[string]any
+
+// Set sets an attribute and returns the payload for chaining.
+func (p Payload) Set(attribute string, value any) Payload {
+	p[attribute] = value
+	return p
+}
+
+// SetLink sets a link field (belongsTo/hasOne) through its {link}Id attribute.
+// The name is optional and only saves the server a lookup when echoing the record.
+func (p Payload) SetLink(link, id, name string) Payload {
+	p[link+"Id"] = id
+	if name != "" {
+		p[link+"Name"] = name
+	}
+	return p
+}
+
+// UnsetLink clears a link field.
+func (p Payload) UnsetLink(link string) Payload {
+	p[link+"Id"] = nil
+	return p
+}
+
+// SetLinkMultiple replaces the related records of a link-multiple field
+// ({link}Ids and, if names is non-nil, {link}Names keyed by ID).
+func (p Payload) SetLinkMultiple(link string, ids []string, names map[string]string) Payload {
+	p[link+"Ids"] = append([]string{}, ids...)
+	if names != nil {
+		p[link+"Names"] = names
+	}
+	return p
+}
+
+// AddLinkMultiple appends IDs to a link-multiple field already set on the payload.
+// Note that EspoCRM treats {link}Ids as the complete list: IDs not in the payload
+// are unlinked, so load the existing IDs first when extending a stored record.
+func (p Payload) AddLinkMultiple(link string, ids ...string) Payload {
+	existing, _ := p[link+"Ids"].([]string)
+	p[link+"Ids"] = append(existing, ids...)
+	return p
+}
+
+// SetLinkParent sets a link-parent field (e.g. "parent" of a Task) through its
+// {link}Type and {link}Id attributes.
+func (p Payload) SetLinkParent(link, entityType, id string) Payload {
+	p[link+"Type"] = entityType
+	p[link+"Id"] = id
+	return p
+}
+
+// asRecord returns data as a record map if it is one.
+func asRecord(data any) (map[string]any, bool) {
+	switch v := data.(type) {
+	case map[string]any:
+		return v, true
+	case Payload:
+		return v, true
+	}
+	return nil, false
+}
@@ -0,0 +1,72 @@
+package espoclient
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EntityPolicy restricts the requests made for one entity type.
+type EntityPolicy struct {
+	// ReadOnly rejects POST, PUT, PATCH and DELETE requests for the entity
+	// client-side, guarding integrations that must never modify it. This covers
+	// mass actions, imports and record actions naming the entity in their body;
+	// exports only read records and are allowed.
+	ReadOnly bool
+	// Timeout bounds requests for the entity unless a WithTimeout option is given.
+	Timeout time.Duration
+}
+
+// SetEntityPolicy sets the policy for requests acting on the entity type.
+func (c *Client) SetEntityPolicy(entity string, policy EntityPolicy) *Client {
+	if c.entityPolicies == nil {
+		c.entityPolicies = map[string]EntityPolicy{}
+	}
+	c.entityPolicies[entity] = policy
+	return c
+}
+
+// bodyAddressedEndpoints are the endpoints that take the entity type they act on
+// from the entityType attribute of the request body rather than from the path.
+var bodyAddressedEndpoints = map[string]bool{"Action": true, "Export": true, "Import": true, "MassAction": true}
+
+// pathEndpoint returns the first segment of a request path, without the query.
+func pathEndpoint(path string) string {
+	endpoint, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	endpoint, _, _ = strings.Cut(endpoint, "?")
+	return endpoint
+}
+
+// requestEntity returns the entity type a request acts on: the first segment of
+// its path, or the entityType of the body for body-addressed endpoints.
+func requestEntity(path string, data any) string {
+	entity := pathEndpoint(path)
+	if bodyAddressedEndpoints[entity] {
+		if body, ok := data.(map[string]any); ok {
+			if target, ok := body["entityType"].(string); ok && target != "" {
+				return target
+			}
+		}
+	}
+	return entity
+}
+
+// entityPolicy returns the policy for the entity a request acts on.
+func (c *Client) entityPolicy(path string, data any) (EntityPolicy, string, bool) {
+	entity := requestEntity(path, data)
+	policy, ok := c.entityPolicies[entity]
+	return policy, entity, ok
+}
+
+// checkEntityPolicy rejects requests that the policy for their entity does not allow.
+func checkEntityPolicy(policy EntityPolicy, entity, method, path string) error {
+	if !policy.ReadOnly || pathEndpoint(path) == "Export" {
+		return nil
+	}
+	switch method {
+	case MethodGet, http.MethodHead, MethodOptions:
+		return nil
+	}
+	return &EspoError{Message: fmt.Sprintf("%s request rejected: entity %s is read-only by policy", method, entity)}
+}
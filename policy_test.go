@@ -0,0 +1,60 @@
+package espoclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestReadOnlyEntityPolicy(t *testing.T) {
+	var sent atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetEntityPolicy("Lead", espoclient.EntityPolicy{ReadOnly: true})
+	ctx := context.Background()
+
+	rejected := map[string]func() error{
+		"query string": func() error {
+			_, err := client.Do(ctx, espoclient.NewRequestSpec(espoclient.MethodPost, "Lead").Query("a", "b"))
+			return err
+		},
+		"mass delete": func() error {
+			_, err := client.MassDelete(ctx, "Lead", espoclient.MassSelection{IDs: []string{"1"}})
+			return err
+		},
+		"record action": func() error {
+			_, err := client.RecordAction(ctx, "Lead", "1", "convertCurrency", nil)
+			return err
+		},
+		"import": func() error {
+			_, err := client.RunImport(ctx, "Lead", "att1", espoclient.ImportParams{})
+			return err
+		},
+	}
+	for name, call := range rejected {
+		sent.Store(0)
+		if err := call(); err == nil || sent.Load() != 0 {
+			t.Errorf("%s: request was sent, want it rejected by the policy", name)
+		}
+	}
+
+	// Other entities and reads of the read-only one are allowed.
+	if _, err := client.MassDelete(ctx, "Contact", espoclient.MassSelection{IDs: []string{"1"}}); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.Request(espoclient.MethodGet, "Lead/1", nil, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,87 @@
+package espoclient
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is the cause of errors reported when a request would exceed the
+// client-side rate limit and RateLimit.FailFast is set.
+var ErrRateLimited = errors.New("client-side rate limit reached")
+
+// RateLimit configures the client-side token bucket limiting the request rate.
+// Every attempt, including retries, takes one token.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate. Zero or less disables the limit.
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent at once after an idle
+	// period; the rate rounded up (at least 1) if zero.
+	Burst int
+	// FailFast returns an error matching ErrRateLimited instead of waiting for a token.
+	FailFast bool
+}
+
+// SetRateLimit limits the rate of requests sent by the client, so bulk scripts
+// stay below the server's throttling limits. By default the rate is unlimited.
+func (c *Client) SetRateLimit(limit RateLimit) *Client {
+	c.limiter = newRateLimiter(limit)
+	return c
+}
+
+// newRateLimiter returns a token bucket for limit, or nil if it is unlimited.
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = max(int(math.Ceil(limit.RequestsPerSecond)), 1)
+	}
+	return &rateLimiter{
+		rate:     limit.RequestsPerSecond,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		failFast: limit.FailFast,
+	}
+}
+
+// rateLimiter is a token bucket refilled at rate tokens per second.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	failFast bool
+}
+
+// wait takes a token, waiting for one to become available unless failing fast.
+func (l *rateLimiter) wait(ctx context.Context, clock Clock) error {
+	for {
+		l.mu.Lock()
+		now := clock.Now()
+		if !l.last.IsZero() {
+			l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if l.failFast {
+			return &EspoError{Message: "request not sent", Cause: ErrRateLimited}
+		}
+		select {
+		case <-clock.After(wait):
+		case <-ctx.Done():
+			return &EspoError{Message: "rate limit wait aborted", Cause: ctx.Err()}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package espoclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// relatedPath builds the {Entity}/{id}/{link} path of a relationship.
+func relatedPath(entity, id, link string) string {
+	return entity + "/" + url.PathEscape(id) + "/" + link
+}
+
+// IterateRelated returns an iterator over the records related to a record through
+// a link (e.g. the contacts of an Account). Pagination, filters, ordering and
+// selection work the same way as for top-level lists.
+func (c *Client) IterateRelated(ctx context.Context, entity, id, link string, params *SearchParams) *Iterator {
+	return c.newIterator(ctx, relatedPath(entity, id, link), params)
+}
+
+// ListRelated returns all records related to a record through a link.
+func (c *Client) ListRelated(ctx context.Context, entity, id, link string, params *SearchParams) ([]map[string]any, error) {
+	return c.listAll(ctx, relatedPath(entity, id, link), params)
+}
+
+// link relates foreign records to a record (POST {Entity}/{id}/{link}).
+func (c *Client) link(ctx context.Context, entity, id, link string, foreignIDs []string) error {
+	_, err := c.request(ctx, MethodPost, relatedPath(entity, id, link), map[string]any{"ids": foreignIDs}, nil)
+	return err
+}
+
+// unlink removes the relation between a record and foreign records (DELETE {Entity}/{id}/{link}).
+func (c *Client) unlink(ctx context.Context, entity, id, link string, foreignIDs []string) error {
+	_, err := c.request(ctx, MethodDelete, relatedPath(entity, id, link), map[string]any{"ids": foreignIDs}, nil)
+	return err
+}
+
+// LinkRecords relates foreign records to a record through a link, e.g. contacts to
+// an Account or teams to a User.
+func (c *Client) LinkRecords(ctx context.Context, entity, id, link string, foreignIDs ...string) error {
+	return c.link(ctx, entity, id, link, foreignIDs)
+}
+
+// UnlinkRecords removes the relation between a record and foreign records.
+func (c *Client) UnlinkRecords(ctx context.Context, entity, id, link string, foreignIDs ...string) error {
+	return c.unlink(ctx, entity, id, link, foreignIDs)
+}
+
+// MassRelate relates every foreign record matching the where clause of params to a
+// record in a single request, evaluated by the server. The where clause must not
+// be empty; use MassRelateAll to relate every foreign record. Ordering, selection
+// and paging parameters are ignored; text, primary and bool filters are not
+// supported and rejected.
+func (c *Client) MassRelate(ctx context.Context, entity, id, link string, params *SearchParams) error {
+	if params.hasFilters() {
+		return &EspoError{Message: "mass relate supports only where clauses, not text, primary or bool filters"}
+	}
+	if params == nil || len(params.Where) == 0 {
+		return &EspoError{Message: "mass relate requires a where clause; use MassRelateAll to relate every record"}
+	}
+	return c.massRelate(ctx, entity, id, link, params.Where)
+}
+
+// MassRelateAll relates every foreign record the API user can access to a record.
+func (c *Client) MassRelateAll(ctx context.Context, entity, id, link string) error {
+	return c.massRelate(ctx, entity, id, link, []WhereItem{})
+}
+
+// massRelate posts a mass relate request for the foreign records matching where.
+func (c *Client) massRelate(ctx context.Context, entity, id, link string, where []WhereItem) error {
+	body := map[string]any{"massRelate": true, "where": where}
+	_, err := c.request(ctx, MethodPost, relatedPath(entity, id, link), body, nil)
+	return err
+}
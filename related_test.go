@@ -0,0 +1,53 @@
+package espoclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+func TestMassRelateSelection(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`true`))
+	}))
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	rejected := map[string]*espoclient.SearchParams{
+		"nil params":   nil,
+		"empty where":  {OrderBy: "name"},
+		"filters only": {PrimaryFilter: "active"},
+	}
+	for name, params := range rejected {
+		body = ""
+		if err := client.MassRelate(ctx, "Account", "a1", "contacts", params); err == nil || body != "" {
+			t.Errorf("%s: sent %s, want the request rejected", name, body)
+		}
+	}
+
+	where := []espoclient.WhereItem{{Type: "isTrue", Attribute: "doNotCall"}}
+	if err := client.MassRelate(ctx, "Account", "a1", "contacts", &espoclient.SearchParams{Where: where}); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"massRelate":true,"where":[{"type":"isTrue","attribute":"doNotCall"}]}`; body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+	if err := client.MassRelateAll(ctx, "Account", "a1", "contacts"); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"massRelate":true,"where":[]}`; body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+}
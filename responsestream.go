@@ -0,0 +1,69 @@
+package espoclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// StreamResponse is a successful response whose body is read from the connection
+// as it arrives. Body must be closed.
+type StreamResponse struct {
+	StatusCode  int
+	ContentType string
+	Headers     http.Header
+	Body        io.ReadCloser
+}
+
+// streamTarget receives the unread body of a successful response.
+type streamTarget struct {
+	Body    io.ReadCloser
+	cancels []context.CancelFunc // released when Body is closed
+}
+
+// RequestStream is like RequestWithContext, but a successful response body is not
+// buffered: it is returned for the caller to read, e.g. to process exports of tens
+// of thousands of records or large attachments with bounded memory. Error responses
+// are buffered and returned as a ResponseError as usual. The client-wide timeout
+// does not apply, as it would cut off slow reads; bound the request with ctx or
+// WithTimeout instead. Payload checksums are not verified for streamed bodies.
+func (c *Client) RequestStream(ctx context.Context, method, path string, data any, headers map[string]string, opts ...RequestOption) (*StreamResponse, error) {
+	target := &streamTarget{}
+	resp, err := c.request(ctx, method, path, data, headers, slices.Concat(opts, []RequestOption{func(o *requestOptions) {
+		o.stream = target
+	}})...)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamResponse{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.ContentType,
+		Headers:     resp.Headers,
+		Body:        &streamBody{ReadCloser: target.Body, cancels: target.cancels},
+	}, nil
+}
+
+// releaseContext cancels a request's context, or defers it until a streamed
+// response body is closed.
+func (o *requestOptions) releaseContext(cancel context.CancelFunc) {
+	if o.stream != nil && o.stream.Body != nil {
+		o.stream.cancels = append(o.stream.cancels, cancel)
+		return
+	}
+	cancel()
+}
+
+// streamBody releases the request's context when the body is closed.
+type streamBody struct {
+	io.ReadCloser
+	cancels []context.CancelFunc
+}
+
+func (b *streamBody) Close() error {
+	err := b.ReadCloser.Close()
+	for _, cancel := range b.cancels {
+		cancel()
+	}
+	return err
+}
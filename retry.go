@@ -0,0 +1,235 @@
+package espoclient
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how requests that failed with a transient error are retried.
+// Network errors and the statuses 429, 502, 503 and 504 are considered transient.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+	// Delay is the pause between attempts when Backoff is nil.
+	Delay time.Duration
+	// Backoff computes the pause before each retry. If nil, Delay is used.
+	Backoff Backoff
+	// Jitter randomizes each pause to spread out retries from many clients: a pause d
+	// becomes a random duration in [d*(1-Jitter), d]. It is clamped to [0, 1].
+	Jitter float64
+	// IgnoreRetryAfter disables honoring the Retry-After header of 429 and 503
+	// responses, which otherwise replaces the computed pause.
+	IgnoreRetryAfter bool
+	// MaxRetryAfter is the longest Retry-After the client waits for; a response asking
+	// for more is returned as the final error instead. Zero means no limit.
+	MaxRetryAfter time.Duration
+	// Methods lists the HTTP methods that may be retried. If empty, only the idempotent
+	// methods GET, HEAD, OPTIONS, PUT and DELETE are retried; other methods require the
+	// RetryNonIdempotent request option.
+	Methods []string
+}
+
+// Backoff decides how long to wait before the next attempt.
+// attempt is the number of the attempt that just failed, starting at 1.
+// lastResponse is the error response of that attempt, or nil for network errors,
+// which allows strategies such as honoring Retry-After first.
+type Backoff interface {
+	NextDelay(attempt int, lastResponse *Response) time.Duration
+}
+
+// BackoffFunc adapts a function to the Backoff interface.
+type BackoffFunc func(attempt int, lastResponse *Response) time.Duration
+
+// NextDelay calls f(attempt, lastResponse).
+func (f BackoffFunc) NextDelay(attempt int, lastResponse *Response) time.Duration {
+	return f(attempt, lastResponse)
+}
+
+// ConstantBackoff waits the same duration before every retry.
+type ConstantBackoff time.Duration
+
+// NextDelay returns the constant delay.
+func (b ConstantBackoff) NextDelay(int, *Response) time.Duration {
+	return time.Duration(b)
+}
+
+// ExponentialBackoff doubles (or multiplies by Multiplier) the pause after every
+// failed attempt, starting at Initial and capped at Max. Combine it with
+// RetryPolicy.Jitter to avoid synchronized retries.
+type ExponentialBackoff struct {
+	Initial    time.Duration // first pause; 500ms if zero
+	Max        time.Duration // upper bound; 30s if zero
+	Multiplier float64       // growth factor; 2 if zero
+}
+
+// NextDelay returns Initial * Multiplier^(attempt-1), capped at Max.
+func (b ExponentialBackoff) NextDelay(attempt int, _ *Response) time.Duration {
+	initial, maxDelay, multiplier := b.Initial, b.Max, b.Multiplier
+	if initial <= 0 {
+		initial = 500 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if d >= float64(maxDelay) {
+		return maxDelay
+	}
+	return time.Duration(d)
+}
+
+// retryDelay returns the pause before the attempt following a failed one, and false if
+// the server asked to wait longer than MaxRetryAfter.
+func (c *Client) retryDelay(p RetryPolicy, attempt int, err error) (time.Duration, bool) {
+	var lastResponse *Response
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		lastResponse = respErr.Response
+	}
+
+	if lastResponse != nil && !p.IgnoreRetryAfter {
+		if wait, ok := retryAfter(lastResponse, c.clock.Now()); ok {
+			if p.MaxRetryAfter > 0 && wait > p.MaxRetryAfter {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	d := p.Delay
+	if p.Backoff != nil {
+		d = p.Backoff.NextDelay(attempt, lastResponse)
+	}
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		d -= time.Duration(float64(d) * jitter * c.rand.Float64())
+	}
+	return d, true
+}
+
+// retryAfter parses the Retry-After header of 429 and 503 responses, given in
+// seconds or as an HTTP date.
+func retryAfter(resp *Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Headers.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// defaultRetryMethods are the idempotent methods retried when RetryPolicy.Methods is empty.
+var defaultRetryMethods = []string{MethodGet, http.MethodHead, MethodOptions, MethodPut, MethodDelete}
+
+// SetRetryPolicy sets the retry policy. By default requests are not retried.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) *Client {
+	c.retryPolicy = policy
+	return c
+}
+
+// allowsMethod reports whether requests with the method may be retried.
+func (p RetryPolicy) allowsMethod(method string, options *requestOptions) bool {
+	if options.retryNonIdempotent {
+		return true
+	}
+	methods := p.Methods
+	if len(methods) == 0 {
+		methods = defaultRetryMethods
+	}
+	return slices.Contains(methods, method)
+}
+
+// isRetryable reports whether the outcome of an attempt is a transient failure.
+func isRetryable(err error) bool {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.Response.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// http.Client reports transport failures as *url.Error; a cancelled or expired
+	// context, or a body over the size limit, is not transient.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) &&
+			!errors.Is(err, ErrBodyTooLarge)
+	}
+	return false
+}
+
+// executeWithRetry sends req, retrying transient failures according to the policy.
+func (c *Client) executeWithRetry(req *http.Request, options *requestOptions) (*Response, error) {
+	policy := c.retryPolicy
+	attempts := 1
+	if policy.MaxAttempts > 1 && policy.allowsMethod(req.Method, options) {
+		attempts = policy.MaxAttempts
+	}
+	// A body that cannot be rewound can only be sent once.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		attempts = 1
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx, c.clock); err != nil {
+				return nil, err
+			}
+		}
+		var dump *RequestDump
+		if c.dump != nil {
+			var err error
+			if dump, err = c.newDump(req, attempt, options); err != nil {
+				return nil, err
+			}
+		}
+		start := c.clock.Now()
+		resp, err := c.execute(req, options)
+		c.logRequest(req, attempt, start, resp, err)
+		if dump != nil {
+			c.finishDump(dump, resp, err)
+		}
+		if err == nil || attempt >= attempts || !isRetryable(err) {
+			return resp, err
+		}
+
+		wait, ok := c.retryDelay(policy, attempt, err)
+		if !ok {
+			return resp, err
+		}
+		select {
+		case <-c.clock.After(wait):
+		case <-ctx.Done():
+			return nil, &EspoError{Message: "retry aborted", Cause: ctx.Err()}
+		}
+
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, &EspoError{Message: "failed to rewind request body", Cause: bodyErr}
+			}
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package espoclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SavedFilter is a filter a user saved in a list view of the UI. EspoCRM keeps
+// saved filters in the user's preferences.
+type SavedFilter struct {
+	Name  string
+	Label string
+	// PrimaryFilter is the primary filter selected when the filter was saved, if any.
+	PrimaryFilter string
+	// Where holds the conditions of the advanced search fields of the filter.
+	Where []WhereItem
+}
+
+// SavedFilters returns the filters the API user saved for the entity type. Log in
+// as a user (or use WithAuthOverride) to read the filters of that user.
+func (c *Client) SavedFilters(ctx context.Context, entity string) ([]SavedFilter, error) {
+	user, err := c.GetAppUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	presets, _ := lookupPath(user.Preferences, "presetFilters", entity).([]any)
+	filters := make([]SavedFilter, 0, len(presets))
+	for _, preset := range presets {
+		p, ok := preset.(map[string]any)
+		if !ok {
+			continue
+		}
+		f := SavedFilter{}
+		f.Name, _ = p["name"].(string)
+		f.Label, _ = p["label"].(string)
+		f.PrimaryFilter, _ = p["primary"].(string)
+		advanced, _ := p["data"].(map[string]any)
+		f.Where = advancedWhere(advanced)
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// SavedFilter returns the saved filter of the entity type with the given name or
+// label. A missing filter fails with an error matching ErrNotFound.
+func (c *Client) SavedFilter(ctx context.Context, entity, name string) (*SavedFilter, error) {
+	filters, err := c.SavedFilters(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	for i := range filters {
+		if filters[i].Name == name || filters[i].Label == name {
+			return &filters[i], nil
+		}
+	}
+	return nil, &EspoError{Message: fmt.Sprintf("no saved %s filter %q", entity, name), Cause: ErrNotFound}
+}
+
+// PrimaryFilters returns the names of the primary filters of the entity type, as
+// offered by the filter menu of its list view.
+func (c *Client) PrimaryFilters(ctx context.Context, entity string) ([]string, error) {
+	md, err := c.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, _ := lookupPath(md, "clientDefs", entity, "filterList").([]any)
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		switch v := item.(type) {
+		case string:
+			names = append(names, v)
+		case map[string]any:
+			if name, ok := v["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// UseFilter applies a saved filter: its primary filter replaces the current one
+// and its conditions are added to the where clause.
+func (p *SearchParams) UseFilter(f *SavedFilter) *SearchParams {
+	if f.PrimaryFilter != "" {
+		p.PrimaryFilter = f.PrimaryFilter
+	}
+	p.Where = append(p.Where, f.Where...)
+	return p
+}
+
+// advancedWhere converts the advanced search data of a saved filter, keyed by
+// field, into where items, in field order.
+func advancedWhere(advanced map[string]any) []WhereItem {
+	fields := make([]string, 0, len(advanced))
+	for field := range advanced {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var where []WhereItem
+	for _, field := range fields {
+		defs, ok := advanced[field].(map[string]any)
+		if !ok {
+			continue
+		}
+		// Some fields store the complete condition.
+		if w, ok := defs["where"]; ok {
+			where = append(where, whereItems(w)...)
+			continue
+		}
+		item, ok := whereItem(defs)
+		if !ok {
+			continue
+		}
+		if item.Attribute == "" {
+			item.Attribute = field
+		}
+		where = append(where, item)
+	}
+	return where
+}
+
+// whereItems decodes a where item or a list of them from their JSON form.
+func whereItems(v any) []WhereItem {
+	switch val := v.(type) {
+	case map[string]any:
+		if item, ok := whereItem(val); ok {
+			return []WhereItem{item}
+		}
+	case []any:
+		var items []WhereItem
+		for _, elem := range val {
+			items = append(items, whereItems(elem)...)
+		}
+		return items
+	}
+	return nil
+}
+
+// whereItem decodes a where item from its JSON form; the value of a group
+// becomes []WhereItem.
+func whereItem(m map[string]any) (WhereItem, bool) {
+	item := WhereItem{Value: m["value"]}
+	item.Type, _ = m["type"].(string)
+	item.Attribute, _ = m["attribute"].(string)
+	if item.Type == "" {
+		return item, false
+	}
+	switch item.Type {
+	case "or", "and", "not":
+		item.Value = whereItems(item.Value)
+	}
+	return item, true
+}
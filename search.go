@@ -0,0 +1,168 @@
+package espoclient
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// WhereItem is a single condition of an EspoCRM where clause.
+// Group types ("or", "and", "not") carry their nested conditions in Value as []WhereItem.
+type WhereItem struct {
+	Type      string `json:"type"`
+	Attribute string `json:"attribute,omitempty"`
+	Value     any    `json:"value,omitempty"`
+}
+
+// SearchParams holds the filtering, ordering and selection parameters accepted
+// by EspoCRM list endpoints. The zero value lists everything in default order.
+type SearchParams struct {
+	Where   []WhereItem
+	OrderBy string
+	Order   string   // "asc" or "desc" (see SortOrder); ascending if empty
+	Select  []string // attributes to return; all if empty
+	MaxSize int      // page size used when iterating; defaultPageSize if zero
+	Limit   int      // maximum number of records returned when iterating; all if zero
+
+	// TextFilter searches the text fields configured for the entity type (full-text
+	// search if enabled), as typed in the search box of a list view; "*" is a wildcard.
+	TextFilter string
+	// PrimaryFilter is a named filter of the entity type, as picked in the filter
+	// menu of a list view, e.g. "open" for cases or "actual" for leads.
+	PrimaryFilter string
+	// BoolFilters are named filters that must all match, e.g. BoolFilterOnlyMy.
+	BoolFilters []string
+	// The filters above also narrow mass actions and exports; MassRelate rejects them.
+}
+
+// Bool filters available for most entity types.
+const (
+	BoolFilterOnlyMy     = "onlyMy"     // records assigned to the API user
+	BoolFilterOnlyMyTeam = "onlyMyTeam" // records of the API user's teams
+	BoolFilterFollowed   = "followed"   // records the API user follows
+	BoolFilterShared     = "shared"     // records shared with the API user
+)
+
+// Values encodes the parameters as list endpoint query parameters.
+// Paging parameters (offset, maxSize) and Limit are not included.
+func (p *SearchParams) Values() url.Values {
+	v := url.Values{}
+	if p == nil {
+		return v
+	}
+	for i, item := range p.Where {
+		encodeWhereItem(v, "where["+strconv.Itoa(i)+"]", item)
+	}
+	if p.OrderBy != "" {
+		v.Set("orderBy", p.OrderBy)
+	}
+	if p.Order != "" {
+		v.Set("order", strings.ToLower(p.Order))
+	}
+	if len(p.Select) > 0 {
+		v.Set("select", strings.Join(p.Select, ","))
+	}
+	if p.TextFilter != "" {
+		v.Set("textFilter", p.TextFilter)
+	}
+	if p.PrimaryFilter != "" {
+		v.Set("primaryFilter", p.PrimaryFilter)
+	}
+	for _, name := range p.BoolFilters {
+		v.Add("boolFilterList[]", name)
+	}
+	return v
+}
+
+// hasFilters reports whether a text, primary or bool filter is set.
+func (p *SearchParams) hasFilters() bool {
+	return p != nil && (p.TextFilter != "" || p.PrimaryFilter != "" || len(p.BoolFilters) > 0)
+}
+
+// addFilters adds the text, primary and bool filters to the searchParams object
+// of a mass action or export request.
+func (p *SearchParams) addFilters(searchParams map[string]any) {
+	if p.TextFilter != "" {
+		searchParams["textFilter"] = p.TextFilter
+	}
+	if p.PrimaryFilter != "" {
+		searchParams["primaryFilter"] = p.PrimaryFilter
+	}
+	if len(p.BoolFilters) > 0 {
+		searchParams["boolFilterList"] = p.BoolFilters
+	}
+}
+
+// SortOrder is the direction in which list results are sorted.
+type SortOrder string
+
+// Sort orders accepted by EspoCRM.
+const (
+	Ascending  SortOrder = "asc"
+	Descending SortOrder = "desc"
+)
+
+// Validate checks the parameters EspoCRM would otherwise reject or ignore.
+func (p *SearchParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	switch SortOrder(strings.ToLower(p.Order)) {
+	case "", Ascending, Descending:
+	default:
+		return &EspoError{Message: fmt.Sprintf("invalid sort order %q (use \"asc\" or \"desc\")", p.Order)}
+	}
+	if p.Order != "" && p.OrderBy == "" {
+		return &EspoError{Message: "sort order given without an attribute to sort by"}
+	}
+	return nil
+}
+
+// pageSize returns the configured page size or the default.
+func (p *SearchParams) pageSize() int {
+	if p == nil || p.MaxSize <= 0 {
+		return defaultPageSize
+	}
+	return p.MaxSize
+}
+
+// limit returns the maximum number of records to iterate over, or 0 for all.
+func (p *SearchParams) limit() int {
+	if p == nil || p.Limit < 0 {
+		return 0
+	}
+	return p.Limit
+}
+
+// encodeWhereItem writes a where condition in the bracket notation EspoCRM parses
+// (where[0][type]=equals&where[0][attribute]=name&where[0][value]=Acme).
+func encodeWhereItem(v url.Values, prefix string, item WhereItem) {
+	v.Set(prefix+"[type]", item.Type)
+	if item.Attribute != "" {
+		v.Set(prefix+"[attribute]", item.Attribute)
+	}
+	encodeWhereValue(v, prefix+"[value]", item.Value)
+}
+
+func encodeWhereValue(v url.Values, key string, value any) {
+	switch val := value.(type) {
+	case nil:
+	case []WhereItem:
+		for i, item := range val {
+			encodeWhereItem(v, key+"["+strconv.Itoa(i)+"]", item)
+		}
+	case []string:
+		for i, s := range val {
+			v.Set(key+"["+strconv.Itoa(i)+"]", s)
+		}
+	case []any:
+		for i, elem := range val {
+			encodeWhereValue(v, key+"["+strconv.Itoa(i)+"]", elem)
+		}
+	case string:
+		v.Set(key, val)
+	default:
+		v.Set(key, fmt.Sprint(val))
+	}
+}
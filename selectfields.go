@@ -0,0 +1,82 @@
+package espoclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// WithSelect limits a read to the given attributes (select=), reducing the payload
+// and the work done by the server. It is merged with SearchParams.Select. Helpers
+// such as ListAll take it from the context (see ContextWithRequestOptions).
+func WithSelect(attributes ...string) RequestOption {
+	return func(o *requestOptions) {
+		o.selectAttrs = append(o.selectAttrs, attributes...)
+	}
+}
+
+// WithLinks adds the attributes of the given links to the select list of a read:
+// the ID and name of a belongsTo link ("accountId", "accountName"), the IDs and
+// names of a link-multiple ("teamsIds", "teamsNames"). Link types are looked up in
+// the metadata. Use Enrich to load the related records themselves.
+func WithLinks(links ...string) RequestOption {
+	return func(o *requestOptions) {
+		o.links = append(o.links, links...)
+	}
+}
+
+// selectValue returns the select parameter of a GET request to path: the existing
+// one merged with the requested attributes and link attributes.
+func (c *Client) selectValue(ctx context.Context, path, existing string, options *requestOptions) (string, error) {
+	var attrs []string
+	if existing != "" {
+		attrs = strings.Split(existing, ",")
+	}
+	attrs = append(attrs, options.selectAttrs...)
+	if len(options.links) > 0 {
+		entity, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+		entity, _, _ = strings.Cut(entity, "?")
+		linkAttrs, err := c.linkAttributes(ctx, entity, options.links)
+		if err != nil {
+			return "", err
+		}
+		attrs = append(attrs, linkAttrs...)
+	}
+
+	seen := make(map[string]bool, len(attrs))
+	unique := attrs[:0]
+	for _, attr := range attrs {
+		if attr != "" && !seen[attr] {
+			seen[attr] = true
+			unique = append(unique, attr)
+		}
+	}
+	return strings.Join(unique, ","), nil
+}
+
+// linkAttributes returns the attributes holding the references of links.
+func (c *Client) linkAttributes(ctx context.Context, entity string, links []string) ([]string, error) {
+	// The metadata request must not inherit select options carried by ctx.
+	ctx = ContextWithRequestOptions(ctx, func(o *requestOptions) {
+		o.selectAttrs, o.links = nil, nil
+	})
+	md, err := c.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var attrs []string
+	for _, link := range links {
+		linkType, _ := lookupPath(md, "entityDefs", entity, "links", link, "type").(string)
+		switch linkType {
+		case "belongsTo", "hasOne":
+			attrs = append(attrs, link+"Id", link+"Name")
+		case "belongsToParent":
+			attrs = append(attrs, link+"Id", link+"Type", link+"Name")
+		case "hasMany", "hasChildren":
+			attrs = append(attrs, link+"Ids", link+"Names")
+		default:
+			return nil, &EspoError{Message: fmt.Sprintf("unknown link %s of %s", link, entity)}
+		}
+	}
+	return attrs, nil
+}
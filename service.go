@@ -0,0 +1,87 @@
+package espoclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// EntityService provides typed record operations for one entity type, decoding
+// records into T (a struct generated by espogen, a hand-written struct or a map).
+type EntityService[T any] struct {
+	Client *Client
+	Entity string
+}
+
+// NewEntityService returns a service for the entity type.
+func NewEntityService[T any](c *Client, entity string) *EntityService[T] {
+	return &EntityService[T]{Client: c, Entity: entity}
+}
+
+// Get fetches a record by ID.
+func (s *EntityService[T]) Get(ctx context.Context, id string) (T, error) {
+	var record T
+	err := s.Client.getRecord(ctx, s.Entity, id, &record)
+	return record, err
+}
+
+// Create creates a record and returns it as stored by the server.
+func (s *EntityService[T]) Create(ctx context.Context, record T) (T, error) {
+	return s.write(ctx, MethodPost, s.Entity, record)
+}
+
+// Update updates a record and returns it as stored by the server.
+// Only the attributes present in the encoded record are changed.
+func (s *EntityService[T]) Update(ctx context.Context, id string, record T) (T, error) {
+	return s.write(ctx, MethodPut, s.Entity+"/"+url.PathEscape(id), record)
+}
+
+// Delete deletes a record.
+func (s *EntityService[T]) Delete(ctx context.Context, id string) error {
+	_, err := s.Client.request(ctx, MethodDelete, s.Entity+"/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+// List returns all records matching params.
+func (s *EntityService[T]) List(ctx context.Context, params *SearchParams) ([]T, error) {
+	var records []T
+	err := s.ForEach(ctx, params, func(record T) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ForEach streams the records matching params to fn (see ForEach).
+func (s *EntityService[T]) ForEach(ctx context.Context, params *SearchParams, fn func(T) error) error {
+	return ForEach(ctx, s.Client, s.Entity, params, fn)
+}
+
+// Link relates foreign records to a record through a link.
+func (s *EntityService[T]) Link(ctx context.Context, id, link string, foreignIDs ...string) error {
+	return s.Client.link(ctx, s.Entity, id, link, foreignIDs)
+}
+
+// Unlink removes the relation between a record and foreign records.
+func (s *EntityService[T]) Unlink(ctx context.Context, id, link string, foreignIDs ...string) error {
+	return s.Client.unlink(ctx, s.Entity, id, link, foreignIDs)
+}
+
+// Related returns an iterator over the records related to a record through a link.
+func (s *EntityService[T]) Related(ctx context.Context, id, link string, params *SearchParams) *Iterator {
+	return s.Client.IterateRelated(ctx, s.Entity, id, link, params)
+}
+
+func (s *EntityService[T]) write(ctx context.Context, method, path string, record T) (T, error) {
+	var stored T
+	resp, err := s.Client.request(ctx, method, path, record, nil)
+	if err != nil {
+		return stored, err
+	}
+	if err := resp.GetParsedBody(&stored); err != nil {
+		return stored, &EspoError{Message: "failed to parse record", Cause: err}
+	}
+	return stored, nil
+}
@@ -0,0 +1,89 @@
+package espoclient
+
+import "context"
+
+// SettingsUpdate describes changes to the system settings for UpdateSettings. Nil
+// fields are left unchanged, so false and zero values can be set explicitly.
+type SettingsUpdate struct {
+	SiteURL         *string
+	TimeZone        *string
+	Language        *string
+	DateFormat      *string // e.g. "DD.MM.YYYY"
+	TimeFormat      *string // e.g. "HH:mm"
+	WeekStart       *int    // 0 for Sunday, 1 for Monday
+	DefaultCurrency *string
+	CurrencyList    []string
+	RecordsPerPage  *int
+
+	OutboundEmailFromName    *string
+	OutboundEmailFromAddress *string
+	// OutboundEmailIsShared lets users send emails from the system address.
+	OutboundEmailIsShared *bool
+	SMTPServer            *string
+	SMTPPort              *int
+	SMTPAuth              *bool
+	SMTPSecurity          *string // "", "SSL" or "TLS"
+	SMTPUsername          *string
+	SMTPPassword          *string
+
+	// Attributes are other settings to change, e.g. "useWebSocket" or
+	// "globalSearchEntityList".
+	Attributes map[string]any
+}
+
+// payload returns the settings to send.
+func (u SettingsUpdate) payload() Payload {
+	data := Payload{}
+	for k, v := range u.Attributes {
+		data[k] = v
+	}
+	setIfNotNil(data, "siteUrl", u.SiteURL)
+	setIfNotNil(data, "timeZone", u.TimeZone)
+	setIfNotNil(data, "language", u.Language)
+	setIfNotNil(data, "dateFormat", u.DateFormat)
+	setIfNotNil(data, "timeFormat", u.TimeFormat)
+	setIfNotNil(data, "weekStart", u.WeekStart)
+	setIfNotNil(data, "defaultCurrency", u.DefaultCurrency)
+	if u.CurrencyList != nil {
+		data["currencyList"] = u.CurrencyList
+	}
+	setIfNotNil(data, "recordsPerPage", u.RecordsPerPage)
+	setIfNotNil(data, "outboundEmailFromName", u.OutboundEmailFromName)
+	setIfNotNil(data, "outboundEmailFromAddress", u.OutboundEmailFromAddress)
+	setIfNotNil(data, "outboundEmailIsShared", u.OutboundEmailIsShared)
+	setIfNotNil(data, "smtpServer", u.SMTPServer)
+	setIfNotNil(data, "smtpPort", u.SMTPPort)
+	setIfNotNil(data, "smtpAuth", u.SMTPAuth)
+	setIfNotNil(data, "smtpSecurity", u.SMTPSecurity)
+	setIfNotNil(data, "smtpUsername", u.SMTPUsername)
+	setIfNotNil(data, "smtpPassword", u.SMTPPassword)
+	return data
+}
+
+// setIfNotNil sets a payload attribute to *value if value is not nil.
+func setIfNotNil[T any](data Payload, attribute string, value *T) {
+	if value != nil {
+		data[attribute] = *value
+	}
+}
+
+// UpdateSettings changes the system settings and returns them as updated.
+// Changing settings requires an admin API user.
+//
+//	server, port, auth := "smtp.example.com", 587, true
+//	settings, err := client.UpdateSettings(ctx, espoclient.SettingsUpdate{
+//		SMTPServer: &server,
+//		SMTPPort:   &port,
+//		SMTPAuth:   &auth,
+//	})
+func (c *Client) UpdateSettings(ctx context.Context, update SettingsUpdate) (*Settings, error) {
+	resp, err := c.request(ctx, MethodPatch, "Settings", update.payload(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var settings Settings
+	if err := resp.GetParsedBody(&settings); err != nil {
+		return nil, &EspoError{Message: "failed to parse settings", Cause: err}
+	}
+	return &settings, nil
+}
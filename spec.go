@@ -0,0 +1,155 @@
+package espoclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SpecError describes one problem found when validating a RequestSpec.
+type SpecError struct {
+	Field   string // "method", "path", "body", "query" or "header"
+	Message string
+}
+
+func (e *SpecError) Error() string {
+	return fmt.Sprintf("espoclient: invalid request %s: %s", e.Field, e.Message)
+}
+
+// RequestSpec is a structured description of an API request. It is validated
+// before anything is sent, so mistakes surface as SpecErrors instead of server errors.
+//
+//	spec := espoclient.NewRequestSpec(espoclient.MethodGet, "Account", id, "contacts").
+//		Params(&espoclient.SearchParams{OrderBy: "name"})
+//	resp, err := client.Do(ctx, spec)
+type RequestSpec struct {
+	method   string
+	segments []string
+	query    url.Values
+	body     any
+	hasBody  bool
+	headers  map[string]string
+	options  []RequestOption
+}
+
+// NewRequestSpec starts a request with the given method and path segments
+// (e.g. "Lead", id). Segments are escaped individually.
+func NewRequestSpec(method string, segments ...string) *RequestSpec {
+	return &RequestSpec{
+		method:   strings.ToUpper(method),
+		segments: segments,
+		query:    url.Values{},
+		headers:  map[string]string{},
+	}
+}
+
+// Path appends path segments.
+func (s *RequestSpec) Path(segments ...string) *RequestSpec {
+	s.segments = append(s.segments, segments...)
+	return s
+}
+
+// Query adds a query parameter.
+func (s *RequestSpec) Query(key, value string) *RequestSpec {
+	s.query.Add(key, value)
+	return s
+}
+
+// Params adds the query parameters encoded from search params.
+func (s *RequestSpec) Params(params *SearchParams) *RequestSpec {
+	for key, vals := range params.Values() {
+		s.query[key] = append(s.query[key], vals...)
+	}
+	return s
+}
+
+// Body sets the request payload; it accepts the same types as Client.Request.
+func (s *RequestSpec) Body(data any) *RequestSpec {
+	s.body = data
+	s.hasBody = true
+	return s
+}
+
+// Header sets a request header.
+func (s *RequestSpec) Header(name, value string) *RequestSpec {
+	s.headers[name] = value
+	return s
+}
+
+// Options adds per-request options.
+func (s *RequestSpec) Options(opts ...RequestOption) *RequestSpec {
+	s.options = append(s.options, opts...)
+	return s
+}
+
+// validMethods are the methods a RequestSpec accepts.
+var validMethods = map[string]bool{
+	MethodGet:       true,
+	MethodPost:      true,
+	MethodPut:       true,
+	MethodPatch:     true,
+	MethodDelete:    true,
+	MethodOptions:   true,
+	http.MethodHead: true,
+}
+
+// Validate checks the spec and returns all problems found, joined; each one is a *SpecError.
+func (s *RequestSpec) Validate() error {
+	var errs []error
+	add := func(field, format string, args ...any) {
+		errs = append(errs, &SpecError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !validMethods[s.method] {
+		add("method", "unknown method %q", s.method)
+	}
+	if len(s.segments) == 0 || s.segments[0] == "" {
+		add("path", "entity type is empty")
+	}
+	for i, segment := range s.segments {
+		if i > 0 && segment == "" {
+			add("path", "segment %d is empty", i)
+		}
+	}
+	if s.hasBody && (s.method == MethodGet || s.method == http.MethodHead) {
+		add("body", "%s requests cannot have a body; use Query or Params instead", s.method)
+	}
+	if s.hasBody && s.body == nil {
+		add("body", "body is nil")
+	}
+	for name := range s.headers {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			add("header", "invalid header name %q", name)
+		}
+	}
+	for key := range s.query {
+		if key == "" {
+			add("query", "empty parameter name")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// path returns the escaped path including the query string.
+func (s *RequestSpec) path() string {
+	escaped := make([]string, len(s.segments))
+	for i, segment := range s.segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	path := strings.Join(escaped, "/")
+	if len(s.query) > 0 {
+		path += "?" + s.query.Encode()
+	}
+	return path
+}
+
+// Do validates the spec and sends it.
+func (c *Client) Do(ctx context.Context, spec *RequestSpec) (*Response, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return c.request(ctx, spec.method, spec.path(), spec.body, spec.headers, spec.options...)
+}
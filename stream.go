@@ -0,0 +1,107 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"time"
+)
+
+// NoteType is the type of a stream Note.
+type NoteType string
+
+// Note types found in EspoCRM streams.
+const (
+	NoteTypePost          NoteType = "Post"
+	NoteTypeCreate        NoteType = "Create"
+	NoteTypeCreateRelated NoteType = "CreateRelated"
+	NoteTypeUpdate        NoteType = "Update"
+	NoteTypeStatus        NoteType = "Status"
+	NoteTypeAssign        NoteType = "Assign"
+	NoteTypeRelate        NoteType = "Relate"
+	NoteTypeUnrelate      NoteType = "Unrelate"
+	NoteTypeEmailReceived NoteType = "EmailReceived"
+	NoteTypeEmailSent     NoteType = "EmailSent"
+)
+
+// Note is an entry of a record's stream (activity feed).
+type Note struct {
+	ID             string          `json:"id"`
+	Type           NoteType        `json:"type"`
+	Post           string          `json:"post,omitempty"`
+	ParentType     string          `json:"parentType,omitempty"`
+	ParentID       string          `json:"parentId,omitempty"`
+	RelatedType    string          `json:"relatedType,omitempty"`
+	RelatedID      string          `json:"relatedId,omitempty"`
+	CreatedAt      string          `json:"createdAt,omitempty"` // UTC, see CreatedTime
+	CreatedByID    string          `json:"createdById,omitempty"`
+	CreatedByName  string          `json:"createdByName,omitempty"`
+	AttachmentsIDs []string        `json:"attachmentsIds,omitempty"`
+	Data           json.RawMessage `json:"data,omitempty"` // type-specific details, e.g. the changed fields of an Update
+}
+
+// CreatedTime parses CreatedAt.
+func (n *Note) CreatedTime() (time.Time, error) {
+	return ParseDateTime(n.CreatedAt, time.UTC)
+}
+
+// IsPost reports whether the note was posted by a user rather than generated by the system.
+func (n *Note) IsPost() bool {
+	return n.Type == NoteTypePost
+}
+
+// NoteFile is a file attached to a posted note.
+type NoteFile struct {
+	Name    string
+	Type    string // MIME type
+	Content io.Reader
+}
+
+// NewNote is a post to a record's stream. Files are uploaded as attachments of the
+// note; AttachmentsIDs references attachments uploaded beforehand.
+type NewNote struct {
+	Post           string
+	Files          []NoteFile
+	AttachmentsIDs []string
+}
+
+// PostToStream posts a note to the stream of a record and returns it as stored.
+func (c *Client) PostToStream(ctx context.Context, entity, id string, note NewNote) (*Note, error) {
+	ids := append([]string(nil), note.AttachmentsIDs...)
+	for _, file := range note.Files {
+		attachmentID, err := c.UploadAttachment(ctx, file.Content, AttachmentMeta{
+			Name:        file.Name,
+			Type:        file.Type,
+			RelatedType: "Note",
+			Field:       "attachments",
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, attachmentID)
+	}
+
+	record := Note{
+		Type:           NoteTypePost,
+		Post:           note.Post,
+		ParentType:     entity,
+		ParentID:       id,
+		AttachmentsIDs: ids,
+	}
+	resp, err := c.request(ctx, MethodPost, "Note", record, nil)
+	if err != nil {
+		return nil, err
+	}
+	var stored Note
+	if err := resp.GetParsedBody(&stored); err != nil {
+		return nil, &EspoError{Message: "failed to parse note", Cause: err}
+	}
+	return &stored, nil
+}
+
+// GetStream returns the notes of a record's stream, newest first, up to params.Limit
+// (all notes if zero); filters such as a NoteType condition go in params.
+func (c *Client) GetStream(ctx context.Context, entity, id string, params *SearchParams) ([]Note, error) {
+	return ListAs[Note](ctx, c, entity+"/"+url.PathEscape(id)+"/stream", params)
+}
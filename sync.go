@@ -0,0 +1,305 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultSyncInterval is the time between runs of Sync.Run if none is configured.
+const defaultSyncInterval = time.Minute
+
+// SyncState is the progress of a Sync. It encodes to JSON, so a service can
+// persist it after each run and resume from it with SetState after a restart.
+type SyncState struct {
+	// Since is the modifiedAt of the most recently modified record delivered.
+	Since string `json:"since,omitempty"`
+	// SeenIDs are the delivered records modified at Since, which are not delivered
+	// again when the next run starts from Since.
+	SeenIDs []string `json:"seenIds,omitempty"`
+	// KnownIDs are the IDs of all records at the end of the last run, kept when
+	// SyncOptions.DetectMissing is set.
+	KnownIDs []string `json:"knownIds,omitempty"`
+}
+
+// SyncOptions configures a Sync.
+type SyncOptions struct {
+	// Params filters the records to sync; its ordering, selection and paging are
+	// replaced. Records that stop matching are only reported by DetectMissing.
+	Params   *SearchParams
+	PageSize int           // defaultPageSize if zero
+	Interval time.Duration // time between runs of Run; one minute if zero
+	// Overlap makes each run also fetch the records modified within this duration
+	// before Since, to catch records saved late with an earlier modifiedAt, e.g. by
+	// long transactions. They are delivered again.
+	Overlap time.Duration
+	// DeletedAttribute is a boolean attribute marking records as deleted, e.g. a
+	// custom "archived" field; such records are passed to OnDelete.
+	DeletedAttribute string
+	// DetectMissing lists the IDs of all records after each run and passes those
+	// that disappeared since the previous run to OnDelete. It costs a full listing
+	// of IDs per run; the first run only records them.
+	DetectMissing bool
+
+	// OnChange is called with each created or modified record, oldest first.
+	OnChange func(ctx context.Context, record map[string]any) error
+	// OnDelete is called with the ID of each deleted record; optional.
+	OnDelete func(ctx context.Context, id string) error
+}
+
+// Sync delivers the records of an entity type modified since its last run to
+// callbacks: the building block of one-way sync services. Records are read in
+// modifiedAt order, so a run interrupted by an error resumes where it stopped.
+// A record modified several times between runs is delivered once, with its latest
+// attributes, and may be delivered again after a failure or because of Overlap,
+// so the callbacks must be idempotent.
+//
+//	s := client.NewSync("Contact", espoclient.SyncOptions{OnChange: upsertContact})
+//	s.SetState(loadState())
+//	err := s.Run(ctx) // every minute until ctx is cancelled
+type Sync struct {
+	client *Client
+	entity string
+	opts   SyncOptions
+
+	running sync.Mutex // held by RunOnce
+	mu      sync.Mutex // guards state
+	state   SyncState
+}
+
+// NewSync returns a Sync of the entity type starting from the beginning: its first
+// run delivers every record. Use SetState to resume.
+func (c *Client) NewSync(entity string, opts SyncOptions) *Sync {
+	return &Sync{client: c, entity: entity, opts: opts}
+}
+
+// State returns the progress of the sync.
+func (s *Sync) State() SyncState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return SyncState{
+		Since:    s.state.Since,
+		SeenIDs:  slices.Clone(s.state.SeenIDs),
+		KnownIDs: slices.Clone(s.state.KnownIDs),
+	}
+}
+
+// SetState resumes the sync from a state returned by State.
+func (s *Sync) SetState(state SyncState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+}
+
+func (s *Sync) setState(state SyncState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+}
+
+// Run calls RunOnce every Interval until ctx is cancelled or a run fails, and
+// returns the error.
+func (s *Sync) Run(ctx context.Context) error {
+	interval := s.opts.Interval
+	if interval <= 0 {
+		interval = defaultSyncInterval
+	}
+	for {
+		if err := s.RunOnce(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-s.client.clock.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// RunOnce delivers the records modified since the previous run, and the deleted
+// ones if configured. The state is updated after each delivered record, so an
+// error from a callback, which RunOnce returns, does not lose progress.
+func (s *Sync) RunOnce(ctx context.Context) error {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	state := s.State()
+	if err := s.syncChanges(ctx, &state); err != nil {
+		return err
+	}
+	if s.opts.DetectMissing {
+		return s.syncMissing(ctx, &state)
+	}
+	return nil
+}
+
+// syncChanges delivers the records modified since state.Since.
+func (s *Sync) syncChanges(ctx context.Context, state *SyncState) error {
+	base := SearchParams{}
+	if s.opts.Params != nil {
+		base = *s.opts.Params
+	}
+	base.OrderBy, base.Order = "modifiedAt", string(Ascending)
+	if len(base.Select) > 0 {
+		base.Select = append(slices.Clone(base.Select), "id", "modifiedAt")
+		if s.opts.DeletedAttribute != "" {
+			base.Select = append(base.Select, s.opts.DeletedAttribute)
+		}
+	}
+	size := s.opts.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+
+	seen := map[string]bool{}
+	for _, id := range state.SeenIDs {
+		seen[id] = true
+	}
+	// Created and deleted records are tracked, so that a record created and deleted
+	// before the next run of syncMissing is still reported as deleted.
+	var known map[string]bool
+	if state.KnownIDs != nil {
+		known = map[string]bool{}
+		for _, id := range state.KnownIDs {
+			known[id] = true
+		}
+		defer func() {
+			state.KnownIDs = sortedKeys(known)
+			s.setState(*state)
+		}()
+	}
+
+	// Pages are read from the modifiedAt of the last record of the previous page.
+	// Records at that time are listed again and skipped; only if a whole page has
+	// the same modifiedAt does the offset grow.
+	from := state.Since
+	if from != "" && s.opts.Overlap > 0 {
+		if t, err := ParseDateTime(from, nil); err == nil {
+			from = FormatDateTime(t.Add(-s.opts.Overlap))
+		}
+	}
+	delivered := map[string]bool{} // IDs listed at from in this run
+	offset := 0
+	for {
+		params := base
+		if from != "" {
+			params.Where = append(slices.Clone(base.Where), GreaterThanOrEquals("modifiedAt", from))
+		}
+		page, err := s.client.listPage(ctx, s.entity, params.Values(), offset, size)
+		if err != nil {
+			return err
+		}
+
+		last, lastIDs := from, delivered // the IDs listed at the last modifiedAt
+		for _, raw := range page.List {
+			var record map[string]any
+			if err := json.Unmarshal(raw, &record); err != nil {
+				return &EspoError{Message: "failed to parse record", Cause: err}
+			}
+			id, _ := record["id"].(string)
+			modifiedAt, _ := record["modifiedAt"].(string)
+			skip := modifiedAt == from && delivered[id] || modifiedAt == state.Since && seen[id]
+			if modifiedAt != last {
+				last, lastIDs = modifiedAt, map[string]bool{}
+			}
+			lastIDs[id] = true
+			if skip {
+				continue
+			}
+
+			if deleted, _ := record[s.opts.DeletedAttribute].(bool); deleted && s.opts.DeletedAttribute != "" {
+				if s.opts.OnDelete != nil {
+					if err := s.opts.OnDelete(ctx, id); err != nil {
+						return &EspoError{Message: "sync callback failed", Cause: err}
+					}
+				}
+				delete(known, id)
+			} else {
+				if s.opts.OnChange != nil {
+					if err := s.opts.OnChange(ctx, record); err != nil {
+						return &EspoError{Message: "sync callback failed", Cause: err}
+					}
+				}
+				if known != nil {
+					known[id] = true
+				}
+			}
+
+			switch {
+			case modifiedAt > state.Since: // datetimes in this format sort as text
+				state.Since, state.SeenIDs = modifiedAt, []string{id}
+				clear(seen)
+				seen[id] = true
+			case modifiedAt == state.Since:
+				state.SeenIDs = append(state.SeenIDs, id)
+				seen[id] = true
+			}
+			s.setState(*state)
+		}
+
+		if len(page.List) < size {
+			return nil
+		}
+		if last == from {
+			offset += len(page.List)
+		} else {
+			from, delivered, offset = last, lastIDs, 0
+		}
+	}
+}
+
+// syncMissing reports the records that disappeared since the previous run and
+// records the current IDs.
+func (s *Sync) syncMissing(ctx context.Context, state *SyncState) error {
+	params := SearchParams{}
+	if s.opts.Params != nil {
+		params = *s.opts.Params
+	}
+	params.Select, params.OrderBy, params.Order, params.Limit = []string{"id"}, "", "", 0
+	params.MaxSize = s.opts.PageSize
+	records, err := s.client.listAll(ctx, s.entity, &params)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]bool, len(records))
+	for _, record := range records {
+		if id, ok := record["id"].(string); ok {
+			current[id] = true
+		}
+	}
+
+	if state.KnownIDs != nil {
+		known := map[string]bool{}
+		for _, id := range state.KnownIDs {
+			known[id] = true
+		}
+		for _, id := range state.KnownIDs {
+			if current[id] {
+				continue
+			}
+			if s.opts.OnDelete != nil {
+				if err := s.opts.OnDelete(ctx, id); err != nil {
+					return &EspoError{Message: "sync callback failed", Cause: err}
+				}
+			}
+			delete(known, id)
+			state.KnownIDs = sortedKeys(known)
+			s.setState(*state)
+		}
+	}
+	state.KnownIDs = sortedKeys(current)
+	s.setState(*state)
+	return nil
+}
+
+// sortedKeys returns the keys of set in order, never nil.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
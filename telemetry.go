@@ -0,0 +1,137 @@
+package espoclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the client's tracer and meter.
+const instrumentationName = "github.com/egorsmkv/go-espo-api-client"
+
+// TelemetryOption enables a part of the OpenTelemetry instrumentation.
+type TelemetryOption func(*telemetry) error
+
+// WithTracerProvider records a span for every API call, including its retries.
+func WithTracerProvider(provider trace.TracerProvider) TelemetryOption {
+	return func(t *telemetry) error {
+		t.tracer = provider.Tracer(instrumentationName)
+		return nil
+	}
+}
+
+// WithMeterProvider records the espo.client.requests counter and the
+// espo.client.request.duration histogram (seconds) for API calls.
+func WithMeterProvider(provider metric.MeterProvider) TelemetryOption {
+	return func(t *telemetry) (err error) {
+		meter := provider.Meter(instrumentationName)
+		t.requests, err = meter.Int64Counter("espo.client.requests",
+			metric.WithDescription("Number of EspoCRM API calls."),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			return err
+		}
+		t.duration, err = meter.Float64Histogram("espo.client.request.duration",
+			metric.WithDescription("Duration of EspoCRM API calls, including retries."),
+			metric.WithUnit("s"))
+		return err
+	}
+}
+
+// telemetry holds the instruments of a client; nil ones are disabled.
+type telemetry struct {
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// SetTelemetry instruments API calls with OpenTelemetry. Spans and metrics carry
+// the HTTP method, the entity type and the response status code. Calling it without
+// options disables the instrumentation.
+//
+//	client.SetTelemetry(
+//		espoclient.WithTracerProvider(otel.GetTracerProvider()),
+//		espoclient.WithMeterProvider(otel.GetMeterProvider()),
+//	)
+func (c *Client) SetTelemetry(opts ...TelemetryOption) error {
+	if len(opts) == 0 {
+		c.telemetry = nil
+		return nil
+	}
+	t := &telemetry{}
+	for _, opt := range opts {
+		if err := opt(t); err != nil {
+			return &EspoError{Message: "failed to set up telemetry", Cause: err}
+		}
+	}
+	c.telemetry = t
+	return nil
+}
+
+// instrument starts the span of an API call to path. The returned function ends it
+// and records the metrics for the call's outcome.
+func (c *Client) instrument(req *http.Request, path string) (*http.Request, func(*Response, error)) {
+	t := c.telemetry
+	if t == nil {
+		return req, func(*Response, error) {}
+	}
+
+	entity, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if i := strings.IndexByte(entity, '?'); i >= 0 {
+		entity = entity[:i]
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("espo.entity", entity),
+	}
+
+	ctx := req.Context()
+	var span trace.Span
+	if t.tracer != nil {
+		ctx, span = t.tracer.Start(ctx, "espo "+req.Method+" "+entity,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+			trace.WithAttributes(attribute.String("url.path", req.URL.Path)))
+		req = req.WithContext(ctx)
+	}
+	start := c.clock.Now()
+
+	return req, func(resp *Response, err error) {
+		var respErr *ResponseError
+		if errors.As(err, &respErr) {
+			resp = respErr.Response
+		}
+		if resp != nil {
+			attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+		}
+		if err != nil {
+			attrs = append(attrs, attribute.Bool("error", true))
+		}
+
+		if span != nil {
+			span.SetAttributes(attrs...)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+
+		// Record even if the call's context is canceled.
+		ctx := context.WithoutCancel(ctx)
+		set := metric.WithAttributes(attrs...)
+		if t.requests != nil {
+			t.requests.Add(ctx, 1, set)
+		}
+		if t.duration != nil {
+			t.duration.Record(ctx, float64(c.clock.Now().Sub(start))/float64(time.Second), set)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// tokenAuth holds the state of the auth token flow.
+type tokenAuth struct {
+	username string
+	password string
+
+	mu    sync.Mutex
+	token string // empty until logged in
+}
+
+// SetTokenAuth authenticates with an auth token instead of sending the password
+// with every request. The client exchanges username and password for a token on
+// the first request (App/user), sends it in the Espo-Authorization header and logs
+// in again when the server rejects an expired token.
+func (c *Client) SetTokenAuth(username, password string) *Client {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.tokenAuth = &tokenAuth{username: username, password: password}
+	c.username, c.password = nil, nil // Clear other auth methods
+	c.apiKey, c.secretKey = nil, nil
+	c.tokenSource = nil
+	return c
+}
+
+// Login exchanges the credentials set with SetTokenAuth for a new auth token.
+// Calling it is optional; it is useful to check the credentials at startup.
+func (c *Client) Login(ctx context.Context) error {
+	t := c.auth().token
+	if t == nil {
+		return &EspoError{Message: "token authentication is not configured"}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return c.login(ctx, t)
+}
+
+// Logout destroys the auth token on the server. The next request logs in again.
+func (c *Client) Logout(ctx context.Context) error {
+	t := c.auth().token
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token == "" {
+		return nil
+	}
+	_, err := c.request(ctx, MethodPost, "App/destroyAuthToken", map[string]any{"token": t.token}, nil, t.authenticate(t.token))
+	t.token = ""
+	return err
+}
+
+// login obtains a token for t; the caller holds t.mu.
+func (c *Client) login(ctx context.Context, t *tokenAuth) error {
+	resp, err := c.request(ctx, MethodGet, "App/user", nil, nil,
+		WithAuthOverride(Credentials{}),
+		WithHeader("Espo-Authorization", espoAuthorization(t.username, t.password)),
+		WithHeader("Espo-Authorization-By-Token", "false"),
+	)
+	if err != nil {
+		return &EspoError{Message: "login failed", Cause: err}
+	}
+	var user AppUser
+	if err := resp.GetParsedBody(&user); err != nil {
+		return &EspoError{Message: "failed to parse login response", Cause: err}
+	}
+	if user.Token == "" {
+		return &EspoError{Message: "login response contains no auth token"}
+	}
+	t.token = user.Token
+	return nil
+}
+
+// authToken returns the current token, logging in if there is none.
+func (c *Client) authToken(ctx context.Context, t *tokenAuth) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token == "" {
+		if err := c.login(ctx, t); err != nil {
+			return "", err
+		}
+	}
+	return t.token, nil
+}
+
+// expire forgets token unless it was already replaced by another request.
+func (t *tokenAuth) expire(token string) {
+	t.mu.Lock()
+	if t.token == token {
+		t.token = ""
+	}
+	t.mu.Unlock()
+}
+
+// authenticate sends a request with token instead of the client's credentials.
+func (t *tokenAuth) authenticate(token string) RequestOption {
+	return func(o *requestOptions) {
+		o.credentials = &Credentials{}
+		if o.headers == nil {
+			o.headers = http.Header{}
+		}
+		o.headers.Set("Espo-Authorization", espoAuthorization(t.username, token))
+		o.headers.Set("Espo-Authorization-By-Token", "true")
+	}
+}
+
+// espoAuthorization encodes the Espo-Authorization header value.
+func espoAuthorization(username, secret string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + secret))
+}
+
+// requestWithToken sends a request authenticated by the auth token. If the server
+// rejects the token as expired, it logs in again and, if retry is set, resends the
+// request once.
+func (c *Client) requestWithToken(ctx context.Context, t *tokenAuth, retry bool, send func(RequestOption) (*Response, error)) (*Response, error) {
+	token, err := c.authToken(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := send(t.authenticate(token))
+	if !errors.Is(err, ErrUnauthorized) {
+		return resp, err
+	}
+
+	t.expire(token)
+	if !retry {
+		return resp, err
+	}
+	if token, err = c.authToken(ctx, t); err != nil {
+		return nil, err
+	}
+	return send(t.authenticate(token))
+}
+
+// replayable reports whether request data can be sent again. Readers other than
+// files are consumed by the first attempt.
+func replayable(data any) bool {
+	switch data.(type) {
+	case *os.File, *Body:
+		return true
+	case io.Reader:
+		return false
+	}
+	return true
+}
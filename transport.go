@@ -0,0 +1,264 @@
+package espoclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DialContextFunc dials a network connection, with the signature of net.Dialer.DialContext.
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// IPFamily selects which IP versions are used to reach the server.
+type IPFamily int
+
+const (
+	// IPFamilyAny dials IPv6 and IPv4 in parallel (happy eyeballs), the Go default.
+	IPFamilyAny IPFamily = iota
+	// IPFamilyPreferIPv4 tries IPv4 addresses first and IPv6 only if all of them fail.
+	IPFamilyPreferIPv4
+	// IPFamilyPreferIPv6 tries IPv6 addresses first and IPv4 only if all of them fail.
+	IPFamilyPreferIPv6
+	// IPFamilyIPv4Only never uses IPv6.
+	IPFamilyIPv4Only
+	// IPFamilyIPv6Only never uses IPv4.
+	IPFamilyIPv6Only
+)
+
+// dialConfig holds the connection settings applied by the client's transport.
+type dialConfig struct {
+	dialer     *net.Dialer
+	custom     DialContextFunc
+	unixSocket string
+	ipFamily   IPFamily
+	hosts      map[string]string // pinned host -> IP
+}
+
+func newDialConfig() *dialConfig {
+	return &dialConfig{
+		dialer: &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Resolver:  net.DefaultResolver,
+		},
+	}
+}
+
+// newTransport returns a transport with the defaults of http.DefaultTransport
+// whose connections are dialed through the client's dial settings. As the client
+// talks to a single host, all idle connections may be kept for it rather than the
+// default two, which concurrent callers would otherwise keep closing and reopening.
+func (c *Client) newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = c.dialContext
+	transport.MaxIdleConnsPerHost = transport.MaxIdleConns
+	return transport
+}
+
+// dialContext opens connections for the client's transport.
+func (c *Client) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	switch {
+	case c.dial.unixSocket != "":
+		return c.dial.dialer.DialContext(ctx, "unix", c.dial.unixSocket)
+	case c.dial.custom != nil:
+		return c.dial.custom(ctx, network, addr)
+	default:
+		return c.dial.dialTCP(ctx, network, addr)
+	}
+}
+
+// dialTCP dials addr honoring pinned hosts and the IP family preference.
+func (d *dialConfig) dialTCP(ctx context.Context, network, addr string) (net.Conn, error) {
+	if host, port, err := net.SplitHostPort(addr); err == nil {
+		if ip, ok := d.hosts[strings.ToLower(host)]; ok {
+			addr = net.JoinHostPort(ip, port)
+		}
+	}
+
+	switch d.ipFamily {
+	case IPFamilyIPv4Only:
+		return d.dialer.DialContext(ctx, "tcp4", addr)
+	case IPFamilyIPv6Only:
+		return d.dialer.DialContext(ctx, "tcp6", addr)
+	case IPFamilyPreferIPv4, IPFamilyPreferIPv6:
+		return d.dialPreferred(ctx, network, addr)
+	default:
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+}
+
+// dialPreferred resolves the host and tries the addresses of the preferred family
+// first, one after another, before falling back to the other family.
+func (d *dialConfig) dialPreferred(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := d.dialer.Resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	wantIPv4 := d.ipFamily == IPFamilyPreferIPv4
+	slices.SortStableFunc(ips, func(a, b net.IPAddr) int {
+		aFirst := (a.IP.To4() != nil) == wantIPv4
+		bFirst := (b.IP.To4() != nil) == wantIPv4
+		switch {
+		case aFirst && !bFirst:
+			return -1
+		case !aFirst && bFirst:
+			return 1
+		}
+		return 0
+	})
+
+	var firstErr error
+	for _, ip := range ips {
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if firstErr == nil {
+		firstErr = &net.DNSError{Err: "no addresses found", Name: host, IsNotFound: true}
+	}
+	return nil, firstErr
+}
+
+// SetUnixSocket sends all requests over the Unix domain socket at path
+// (e.g. a docker-compose sidecar or an on-host proxy). The base URL is still used
+// for the Host header, TLS server name and request signing.
+// Dial settings only apply to the client's own transport, not one installed with SetHTTPClient.
+func (c *Client) SetUnixSocket(path string) *Client {
+	c.dial.unixSocket = path
+	return c
+}
+
+// SetDialContext makes the client open connections with dial instead of the
+// default dialer, while the base URL keeps determining the Host header, TLS server
+// name and request signing. addr is the host:port of the base URL.
+// Dial settings only apply to the client's own transport, not one installed with SetHTTPClient.
+func (c *Client) SetDialContext(dial DialContextFunc) *Client {
+	c.dial.custom = dial
+	return c
+}
+
+// SetSOCKS5Proxy routes all requests through a SOCKS5 proxy (e.g. an SSH tunnel
+// opened with "ssh -D"). addr is host:port; username and password may be empty
+// if the proxy does not require authentication. Hostnames are resolved by the proxy.
+// Proxy settings only apply to the client's own transport, not one installed with SetHTTPClient.
+func (c *Client) SetSOCKS5Proxy(addr, username, password string) *Client {
+	proxyURL := &url.URL{Scheme: "socks5", Host: addr}
+	if username != "" || password != "" {
+		proxyURL.User = url.UserPassword(username, password)
+	}
+	return c.SetProxyURL(proxyURL)
+}
+
+// SetProxyURL routes all requests through the proxy at proxyURL. The http, https
+// and socks5 schemes are supported. A nil URL disables proxying, including the
+// HTTP_PROXY/HTTPS_PROXY environment variables honored by default.
+func (c *Client) SetProxyURL(proxyURL *url.URL) *Client {
+	if proxyURL == nil {
+		c.transport.Proxy = nil
+	} else {
+		c.transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return c
+}
+
+// SetTLSConfig sets the TLS configuration of the client's transport, e.g. to trust
+// a private CA or present a client certificate.
+// TLS settings only apply to the client's own transport, not one installed with SetHTTPClient.
+func (c *Client) SetTLSConfig(config *tls.Config) *Client {
+	c.transport.TLSClientConfig = config
+	return c
+}
+
+// ConnectionPool tunes how the client's transport reuses connections. Zero and
+// nil fields keep the current setting.
+type ConnectionPool struct {
+	// MaxIdleConns is the number of idle connections kept for reuse (100 by default).
+	MaxIdleConns int
+	// MaxConnsPerHost limits the connections open at once, including those in use;
+	// requests beyond it wait for a connection. Unlimited by default.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes connections idle for longer (90s by default). Keep it
+	// below the keep-alive timeout of the server or load balancer in front of it.
+	IdleConnTimeout time.Duration
+	// KeepAlive is the interval of TCP keep-alive probes (30s by default); negative
+	// disables them.
+	KeepAlive time.Duration
+	// DisableKeepAlives opens a new connection for every request if true and
+	// restores connection reuse if false.
+	DisableKeepAlives *bool
+}
+
+// SetConnectionPool tunes connection reuse, e.g. raising MaxIdleConns for sync jobs
+// with many concurrent requests so that connections are not closed and reopened,
+// or capping MaxConnsPerHost so that they do not exhaust sockets.
+// Pool settings only apply to the client's own transport, not one installed with SetHTTPClient.
+func (c *Client) SetConnectionPool(pool ConnectionPool) *Client {
+	t := c.transport
+	if pool.MaxIdleConns > 0 {
+		t.MaxIdleConns = pool.MaxIdleConns
+		t.MaxIdleConnsPerHost = pool.MaxIdleConns
+	}
+	if pool.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = pool.MaxConnsPerHost
+	}
+	if pool.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = pool.IdleConnTimeout
+	}
+	if pool.KeepAlive != 0 {
+		c.dial.dialer.KeepAlive = pool.KeepAlive
+	}
+	if pool.DisableKeepAlives != nil {
+		t.DisableKeepAlives = *pool.DisableKeepAlives
+	}
+	return c
+}
+
+// CloseIdleConnections closes the idle connections of the client's transport, e.g.
+// after a burst of requests.
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// SetIPFamily selects which IP versions are used to reach the server, e.g.
+// IPFamilyPreferIPv4 for hosts that publish broken AAAA records.
+func (c *Client) SetIPFamily(family IPFamily) *Client {
+	c.dial.ipFamily = family
+	return c
+}
+
+// SetDualStackFallbackDelay sets how long IPFamilyAny waits for the first address
+// family before racing the other one (300ms by default). A negative delay disables
+// the parallel fallback.
+func (c *Client) SetDualStackFallbackDelay(delay time.Duration) *Client {
+	c.dial.dialer.FallbackDelay = delay
+	return c
+}
+
+// SetHostResolution pins host to ip, bypassing DNS (split-horizon DNS, blue/green
+// cutovers). Only the dialed address changes: the Host header, TLS server name and
+// request signing keep using the hostname of the base URL. When a proxy is
+// configured the pin applies to the proxy's hostname instead.
+func (c *Client) SetHostResolution(host, ip string) *Client {
+	if c.dial.hosts == nil {
+		c.dial.hosts = map[string]string{}
+	}
+	c.dial.hosts[strings.ToLower(host)] = ip
+	return c
+}
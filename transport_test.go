@@ -0,0 +1,43 @@
+package espoclient_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+)
+
+// A later SetConnectionPool call without DisableKeepAlives keeps the setting.
+func TestSetConnectionPoolKeepsKeepAlives(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+	client, err := espoclient.NewClient(srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disable := true
+	client.SetConnectionPool(espoclient.ConnectionPool{DisableKeepAlives: &disable})
+	client.SetConnectionPool(espoclient.ConnectionPool{MaxConnsPerHost: 10})
+	for range 3 {
+		if _, err := client.Request(espoclient.MethodGet, "App/user", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := conns.Load(); got != 3 {
+		t.Errorf("server saw %d connections, want one per request", got)
+	}
+}
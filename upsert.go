@@ -0,0 +1,71 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// UpsertOptions configures UpsertEntity.
+type UpsertOptions struct {
+	// IfModifiedAt enables optimistic concurrency: an existing record is only
+	// updated if its modifiedAt still equals this value, as read by the caller.
+	// Otherwise the upsert fails with an error matching ErrStaleRecord.
+	IfModifiedAt string
+}
+
+// UpsertEntity updates the record of the entity whose matchField (a unique field
+// such as emailAddress or an external ID) has the same value as record, or creates
+// record if there is none. It returns the record as stored and whether it was
+// created. Several matching records fail the upsert. An emailAddress or phoneNumber
+// match field also matches secondary addresses and numbers, as FindByEmailAddress
+// and FindByPhoneNumber do.
+//
+// The lookup and the write are separate requests, so the upsert is not atomic: a
+// record created by someone else in between is not seen, and may be duplicated.
+// Serialize upserts of the same value, or rely on a unique index on the server.
+func (c *Client) UpsertEntity(ctx context.Context, entity, matchField string, record map[string]any, opts UpsertOptions) (map[string]any, bool, error) {
+	return c.upsert(ctx, entity, matchField, record, opts)
+}
+
+// upsert implements UpsertEntity.
+func (c *Client) upsert(ctx context.Context, entity, matchField string, record map[string]any, opts UpsertOptions) (map[string]any, bool, error) {
+	value, ok := record[matchField]
+	if !ok || value == nil || value == "" {
+		return nil, false, &EspoError{Message: fmt.Sprintf("record has no value for match field %s", matchField)}
+	}
+
+	params := &SearchParams{Where: []WhereItem{c.matchCondition(matchField, value)}, Select: []string{"id", "modifiedAt"}}
+	page, err := c.listPage(ctx, entity, params.Values(), 0, 2)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(page.List) == 0 {
+		stored, err := c.CreateEntity(ctx, entity, record)
+		return stored, err == nil, err
+	}
+
+	type match struct {
+		ID         string `json:"id"`
+		ModifiedAt string `json:"modifiedAt"`
+	}
+	matches := make([]match, len(page.List))
+	for i, raw := range page.List {
+		if err := json.Unmarshal(raw, &matches[i]); err != nil {
+			return nil, false, &EspoError{Message: "failed to parse record", Cause: err}
+		}
+	}
+	existing := matches[0]
+	// A record with two matching addresses may be listed twice.
+	if len(matches) > 1 && matches[1].ID != existing.ID {
+		return nil, false, &EspoError{Message: fmt.Sprintf("several %s records have %s %v", entity, matchField, value)}
+	}
+	if opts.IfModifiedAt != "" && existing.ModifiedAt != opts.IfModifiedAt {
+		return nil, false, &EspoError{
+			Message: fmt.Sprintf("%s %s was modified at %s", entity, existing.ID, existing.ModifiedAt),
+			Cause:   ErrStaleRecord,
+		}
+	}
+	stored, err := c.UpdateEntity(ctx, entity, existing.ID, record)
+	return stored, false, err
+}
@@ -0,0 +1,38 @@
+package espoclient_test
+
+import (
+	"context"
+	"testing"
+
+	espoclient "github.com/egorsmkv/go-espo-api-client"
+	"github.com/egorsmkv/go-espo-api-client/espoclienttest"
+)
+
+func TestUpsertMatchesSecondaryEmailAddress(t *testing.T) {
+	srv := espoclienttest.NewServer()
+	defer srv.Close()
+	ids := srv.Seed("Contact", map[string]any{
+		"lastName":     "Doe",
+		"emailAddress": "jane@example.com",
+		"emailAddressData": []any{
+			map[string]any{"emailAddress": "jane@example.com", "primary": true},
+			map[string]any{"emailAddress": "j.doe@example.org", "primary": false},
+		},
+	})
+	client, err := srv.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := map[string]any{"emailAddress": "J.Doe@Example.org", "title": "CTO"}
+	stored, created, err := client.UpsertEntity(context.Background(), "Contact", "emailAddress", record, espoclient.UpsertOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created || stored["id"] != ids[0] {
+		t.Errorf("upsert created %v (id %v), want an update of %s", created, stored["id"], ids[0])
+	}
+	if n := len(srv.Records("Contact")); n != 1 {
+		t.Errorf("%d contacts after the upsert, want 1", n)
+	}
+}
@@ -0,0 +1,184 @@
+package espoclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// User types.
+const (
+	UserTypeRegular = "regular"
+	UserTypeAdmin   = "admin"
+	UserTypeAPI     = "api"
+	UserTypePortal  = "portal"
+)
+
+// NewUser describes a user to create with CreateUser.
+type NewUser struct {
+	UserName     string
+	FirstName    string
+	LastName     string
+	EmailAddress string
+	Type         string // UserTypeRegular if empty
+	// Password is the initial password of a regular, admin or portal user. If empty,
+	// the user cannot log in until a password is set, e.g. with GenerateUserPassword.
+	Password string
+	// SendAccessInfo emails the user their login details.
+	SendAccessInfo bool
+	// AuthMethod is "ApiKey" or "Hmac" for an API user; "ApiKey" if empty.
+	AuthMethod    string
+	TeamIDs       []string
+	DefaultTeamID string
+	RoleIDs       []string
+	// Attributes are other attributes of the user, e.g. "title" or "phoneNumber".
+	Attributes map[string]any
+}
+
+// CreatedUser is a user as created by CreateUser.
+type CreatedUser struct {
+	UserInfo
+	// APIKey and SecretKey are the generated credentials of an API user; SecretKey
+	// is only set for HMAC authentication.
+	APIKey    string `json:"apiKey,omitempty"`
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+// CreateUser creates a user, with its teams and roles. Creating users requires an
+// admin API user.
+func (c *Client) CreateUser(ctx context.Context, user NewUser) (*CreatedUser, error) {
+	data := Payload{}
+	for k, v := range user.Attributes {
+		data[k] = v
+	}
+	data["userName"] = user.UserName
+	data["type"] = user.Type
+	if user.Type == "" {
+		data["type"] = UserTypeRegular
+	}
+	setIfNotEmpty(data, "firstName", user.FirstName)
+	setIfNotEmpty(data, "lastName", user.LastName)
+	setIfNotEmpty(data, "emailAddress", user.EmailAddress)
+	if user.Password != "" {
+		data["password"] = user.Password
+		data["passwordConfirm"] = user.Password
+	}
+	if user.SendAccessInfo {
+		data["sendAccessInfo"] = true
+	}
+	if user.Type == UserTypeAPI {
+		data["authMethod"] = user.AuthMethod
+		if user.AuthMethod == "" {
+			data["authMethod"] = "ApiKey"
+		}
+	}
+	if len(user.TeamIDs) > 0 {
+		data.SetLinkMultiple("teams", user.TeamIDs, nil)
+	}
+	setIfNotEmpty(data, "defaultTeamId", user.DefaultTeamID)
+	if len(user.RoleIDs) > 0 {
+		data.SetLinkMultiple("roles", user.RoleIDs, nil)
+	}
+
+	resp, err := c.request(ctx, MethodPost, "User", data, nil)
+	if err != nil {
+		return nil, err
+	}
+	var created CreatedUser
+	if err := resp.GetParsedBody(&created); err != nil {
+		return nil, &EspoError{Message: "failed to parse user", Cause: err}
+	}
+	return &created, nil
+}
+
+// setIfNotEmpty sets an attribute of p if value is not empty.
+func setIfNotEmpty(p Payload, attr, value string) {
+	if value != "" {
+		p[attr] = value
+	}
+}
+
+// userPath returns the path of a user record.
+func userPath(id string) string {
+	return "User/" + url.PathEscape(id)
+}
+
+// SetUserPassword sets the password of a user, as an admin does in the UI.
+func (c *Client) SetUserPassword(ctx context.Context, userID, password string) error {
+	_, err := c.request(ctx, MethodPatch, userPath(userID), map[string]any{
+		"password":        password,
+		"passwordConfirm": password,
+	}, nil)
+	return err
+}
+
+// GenerateUserPassword makes the server generate a new password for a user and
+// email it to them. The instance must have outbound email configured.
+func (c *Client) GenerateUserPassword(ctx context.Context, userID string) error {
+	_, err := c.Action(ctx, "User", userID, "generateNewPassword", nil)
+	return err
+}
+
+// ActivateUser allows a user to log in again.
+func (c *Client) ActivateUser(ctx context.Context, userID string) error {
+	return c.setUserActive(ctx, userID, true)
+}
+
+// DeactivateUser prevents a user from logging in and using the API, keeping their
+// records and history. It is the usual way to offboard a user.
+func (c *Client) DeactivateUser(ctx context.Context, userID string) error {
+	return c.setUserActive(ctx, userID, false)
+}
+
+func (c *Client) setUserActive(ctx context.Context, userID string, active bool) error {
+	_, err := c.request(ctx, MethodPatch, userPath(userID), map[string]any{"isActive": active}, nil)
+	return err
+}
+
+// AddUserToTeams adds a user to teams.
+func (c *Client) AddUserToTeams(ctx context.Context, userID string, teamIDs ...string) error {
+	return c.link(ctx, "User", userID, "teams", teamIDs)
+}
+
+// RemoveUserFromTeams removes a user from teams.
+func (c *Client) RemoveUserFromTeams(ctx context.Context, userID string, teamIDs ...string) error {
+	return c.unlink(ctx, "User", userID, "teams", teamIDs)
+}
+
+// AssignRoles assigns roles to a user.
+func (c *Client) AssignRoles(ctx context.Context, userID string, roleIDs ...string) error {
+	return c.link(ctx, "User", userID, "roles", roleIDs)
+}
+
+// RevokeRoles removes roles from a user.
+func (c *Client) RevokeRoles(ctx context.Context, userID string, roleIDs ...string) error {
+	return c.unlink(ctx, "User", userID, "roles", roleIDs)
+}
+
+// CreateTeam creates a team with the given roles, which apply to all its users,
+// and returns its ID.
+func (c *Client) CreateTeam(ctx context.Context, name string, roleIDs ...string) (string, error) {
+	data := Payload{"name": name}
+	if len(roleIDs) > 0 {
+		data.SetLinkMultiple("roles", roleIDs, nil)
+	}
+	team, err := c.CreateEntity(ctx, "Team", data)
+	if err != nil {
+		return "", err
+	}
+	id, _ := team["id"].(string)
+	return id, nil
+}
+
+// FindUserByName returns the user with the given user name, or an error matching
+// ErrNotFound if there is none.
+func (c *Client) FindUserByName(ctx context.Context, userName string) (*UserInfo, error) {
+	params := &SearchParams{Where: []WhereItem{Equals("userName", userName)}, Limit: 1}
+	users, err := ListAs[UserInfo](ctx, c, "User", params)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, &EspoError{Message: "no user named " + userName, Cause: ErrNotFound}
+	}
+	return &users[0], nil
+}
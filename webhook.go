@@ -0,0 +1,74 @@
+package espoclient
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidSignature is returned when a webhook request's signature does not match.
+var ErrInvalidSignature = errors.New("espoclient: invalid webhook signature")
+
+// Webhook is a subscription of a URL to EspoCRM events.
+type Webhook struct {
+	ID string `json:"id,omitempty"`
+	// Event is "{Entity}.create", "{Entity}.update", "{Entity}.delete" or
+	// "{Entity}.fieldUpdate.{field}", e.g. "Contact.create".
+	Event    string `json:"event"`
+	URL      string `json:"url"`
+	IsActive bool   `json:"isActive"`
+	// SecretKey signs the requests sent for the webhook. It is generated by the
+	// server and returned when the webhook is created.
+	SecretKey string `json:"secretKey,omitempty"`
+	UserID    string `json:"userId,omitempty"`
+}
+
+// CreateWebhook subscribes targetURL to an event and returns the webhook, including
+// the secret key needed to verify its requests.
+func (c *Client) CreateWebhook(ctx context.Context, event, targetURL string) (*Webhook, error) {
+	resp, err := c.request(ctx, MethodPost, "Webhook", Webhook{Event: event, URL: targetURL, IsActive: true}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var webhook Webhook
+	if err := resp.GetParsedBody(&webhook); err != nil {
+		return nil, &EspoError{Message: "failed to parse webhook", Cause: err}
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks returns the webhooks matching params visible to the API user.
+func (c *Client) ListWebhooks(ctx context.Context, params *SearchParams) ([]Webhook, error) {
+	return ListAs[Webhook](ctx, c, "Webhook", params)
+}
+
+// DeleteWebhook removes a webhook subscription.
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	_, err := c.request(ctx, MethodDelete, "Webhook/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+// VerifyWebhookSignature checks the X-Signature header of a webhook request against
+// the raw request body. EspoCRM signs requests with
+// base64(webhookID + ":" + HMAC-SHA256(body, secretKey)). It returns the ID of the
+// webhook that sent the request, or ErrInvalidSignature.
+func VerifyWebhookSignature(signature string, body []byte, secretKey string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	webhookID, sum, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write(body)
+	if !hmac.Equal([]byte(sum), mac.Sum(nil)) {
+		return "", ErrInvalidSignature
+	}
+	return webhookID, nil
+}
@@ -0,0 +1,139 @@
+package espoclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxWebhookBodySize bounds the body read by WebhookHandler.
+const maxWebhookBodySize = 10 << 20
+
+// WebhookEvent is a batch of records delivered by a webhook.
+type WebhookEvent struct {
+	WebhookID string
+	Event     string // e.g. "Contact.update" or "Contact.fieldUpdate.status"
+	Entity    string // e.g. "Contact"
+	Action    string // "create", "update", "delete" or "fieldUpdate"
+	// Records holds the records the event is about. Delete events carry only the IDs.
+	Records []map[string]any
+}
+
+// WebhookFunc handles a webhook event. Returning an error responds with 500, which
+// makes EspoCRM retry the delivery later.
+type WebhookFunc func(ctx context.Context, event WebhookEvent) error
+
+// WebhookHandler is an http.Handler receiving EspoCRM webhook requests. It verifies
+// the X-Signature of each request with the secret key of the sending webhook and
+// dispatches the payload to the callbacks registered for its event.
+//
+//	h := espoclient.NewWebhookHandler()
+//	h.AddWebhook(webhook) // as returned by CreateWebhook
+//	h.On("Contact.create", func(ctx context.Context, e espoclient.WebhookEvent) error { ... })
+//	http.Handle("/espo/webhook", h)
+type WebhookHandler struct {
+	mu       sync.RWMutex
+	webhooks map[string]Webhook
+	handlers map[string][]WebhookFunc
+}
+
+// NewWebhookHandler returns a handler with no webhooks or callbacks.
+func NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{webhooks: map[string]Webhook{}, handlers: map[string][]WebhookFunc{}}
+}
+
+// AddWebhook accepts requests from the webhook; its ID, Event and SecretKey must be set.
+func (h *WebhookHandler) AddWebhook(webhook *Webhook) *WebhookHandler {
+	h.mu.Lock()
+	h.webhooks[webhook.ID] = *webhook
+	h.mu.Unlock()
+	return h
+}
+
+// On registers fn for an event. The pattern is an event name ("Contact.create"),
+// all events of an entity ("Contact.*") or every event ("*").
+func (h *WebhookHandler) On(pattern string, fn WebhookFunc) *WebhookHandler {
+	h.mu.Lock()
+	h.handlers[pattern] = append(h.handlers[pattern], fn)
+	h.mu.Unlock()
+	return h
+}
+
+// ServeHTTP verifies and dispatches a webhook request.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	webhook, ok := h.verify(r.Header.Get("X-Signature"), body)
+	if !ok {
+		http.Error(w, ErrInvalidSignature.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var records []map[string]any
+	if err := json.Unmarshal(body, &records); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	entity, action, _ := strings.Cut(webhook.Event, ".")
+	action, _, _ = strings.Cut(action, ".")
+	event := WebhookEvent{
+		WebhookID: webhook.ID,
+		Event:     webhook.Event,
+		Entity:    entity,
+		Action:    action,
+		Records:   records,
+	}
+	for _, fn := range h.callbacks(webhook.Event, entity) {
+		if err := fn(r.Context(), event); err != nil {
+			http.Error(w, "webhook handler failed", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify returns the registered webhook whose secret key signed body. The
+// signature names the webhook, whose secret key is then checked.
+func (h *WebhookHandler) verify(signature string, body []byte) (Webhook, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return Webhook{}, false
+	}
+	id, _, _ := strings.Cut(string(decoded), ":")
+
+	h.mu.RLock()
+	webhook, ok := h.webhooks[id]
+	h.mu.RUnlock()
+	if !ok {
+		return Webhook{}, false
+	}
+	if _, err := VerifyWebhookSignature(signature, body, webhook.SecretKey); err != nil {
+		return Webhook{}, false
+	}
+	return webhook, true
+}
+
+// callbacks returns the callbacks matching an event, most specific first.
+func (h *WebhookHandler) callbacks(event, entity string) []WebhookFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var fns []WebhookFunc
+	fns = append(fns, h.handlers[event]...)
+	fns = append(fns, h.handlers[entity+".*"]...)
+	fns = append(fns, h.handlers["*"]...)
+	return fns
+}
@@ -0,0 +1,222 @@
+package espoclient
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// This file implements the client side of the WebSocket protocol (RFC 6455), as
+// far as the event stream needs it: text messages, fragmentation, ping, pong and
+// close. The connection is opened through the client's HTTP client, so proxies,
+// TLS settings and custom dialers apply to it.
+
+// WebSocket opcodes.
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xA
+)
+
+// wsMaxMessageSize bounds the size of a received message.
+const wsMaxMessageSize = 16 << 20
+
+// wsGUID is appended to the handshake key to compute the accept key.
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// errWebSocketClosed is returned when the server closes the connection.
+var errWebSocketClosed = errors.New("websocket closed by the server")
+
+// wsConn is a client WebSocket connection.
+type wsConn struct {
+	rwc    io.ReadWriteCloser
+	br     *bufio.Reader
+	onRead func() // called for every frame read, if set
+
+	writeMu sync.Mutex
+}
+
+// dialWebSocket opens a WebSocket connection to u (ws or wss) with hc.
+func dialWebSocket(ctx context.Context, hc *http.Client, u *url.URL, protocol string) (*wsConn, *http.Response, error) {
+	httpURL := *u
+	switch u.Scheme {
+	case "ws":
+		httpURL.Scheme = "http"
+	case "wss":
+		httpURL.Scheme = "https"
+	}
+	req, err := http.NewRequestWithContext(ctx, MethodGet, httpURL.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	if protocol != "" {
+		req.Header.Set("Sec-WebSocket-Protocol", protocol)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		resp.Body.Close()
+		return nil, resp, fmt.Errorf("websocket handshake failed: %s", resp.Status)
+	}
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		return nil, resp, errors.New("websocket handshake failed: connection is not writable")
+	}
+	sum := sha1.Sum([]byte(key + wsGUID))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) ||
+		!strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		rwc.Close()
+		return nil, resp, errors.New("websocket handshake failed: invalid upgrade response")
+	}
+	return &wsConn{rwc: rwc, br: bufio.NewReader(rwc)}, resp, nil
+}
+
+// writeFrame sends a single masked frame, as clients must.
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := make([]byte, 2, 14)
+	header[0] = 0x80 | opcode // FIN
+	switch n := len(payload); {
+	case n < 126:
+		header[1] = 0x80 | byte(n)
+	case n <= 0xFFFF:
+		header[1] = 0x80 | 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 0x80 | 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+	mask := make([]byte, 4)
+	rand.Read(mask)
+	header = append(header, mask...)
+
+	masked := make([]byte, len(payload))
+	for i, b := range payload {
+		masked[i] = b ^ mask[i%4]
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.rwc.Write(append(header, masked...))
+	return err
+}
+
+// writeText sends a text message.
+func (c *wsConn) writeText(data []byte) error {
+	return c.writeFrame(wsText, data)
+}
+
+// ping sends a ping frame.
+func (c *wsConn) ping() error {
+	return c.writeFrame(wsPing, nil)
+}
+
+// readMessage returns the next data message, answering pings on the way. It
+// returns errWebSocketClosed when the server closes the connection.
+func (c *wsConn) readMessage() ([]byte, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case wsPing:
+			if err := c.writeFrame(wsPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			c.writeFrame(wsClose, nil)
+			return nil, errWebSocketClosed
+		case wsText, wsBinary, wsContinuation:
+		default:
+			return nil, fmt.Errorf("websocket: unknown opcode %d", opcode)
+		}
+		if len(message)+len(payload) > wsMaxMessageSize {
+			return nil, errors.New("websocket: message too large")
+		}
+		message = append(message, payload...)
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+// readFrame reads a single frame.
+func (c *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.br, head[:]); err != nil {
+		return
+	}
+	fin, opcode = head[0]&0x80 != 0, head[0]&0x0F
+	length := uint64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > wsMaxMessageSize {
+		err = errors.New("websocket: message too large")
+		return
+	}
+	var mask [4]byte
+	masked := head[1]&0x80 != 0
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	if c.onRead != nil {
+		c.onRead()
+	}
+	return
+}
+
+// close sends a close frame and closes the connection.
+func (c *wsConn) close() error {
+	c.writeFrame(wsClose, []byte{0x03, 0xE8}) // 1000, normal closure
+	return c.rwc.Close()
+}
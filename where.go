@@ -0,0 +1,182 @@
+package espoclient
+
+// Constructors for the where conditions of SearchParams. They combine into nested
+// groups and are encoded into the query parameters EspoCRM expects:
+//
+//	params := new(espoclient.SearchParams).
+//		Filter(
+//			espoclient.Equals("status", "New"),
+//			espoclient.Or(
+//				espoclient.Contains("name", "Acme"),
+//				espoclient.In("industry", "Retail", "Finance"),
+//			),
+//			espoclient.Between("createdAt", "2024-01-01", "2024-12-31"),
+//		).
+//		Sort("createdAt", "desc").
+//		Fields("id", "name")
+
+// Equals matches records whose attribute equals value.
+func Equals(attribute string, value any) WhereItem {
+	return WhereItem{Type: "equals", Attribute: attribute, Value: value}
+}
+
+// NotEquals matches records whose attribute differs from value.
+func NotEquals(attribute string, value any) WhereItem {
+	return WhereItem{Type: "notEquals", Attribute: attribute, Value: value}
+}
+
+// Contains matches records whose attribute contains the substring.
+func Contains(attribute, substring string) WhereItem {
+	return WhereItem{Type: "contains", Attribute: attribute, Value: substring}
+}
+
+// NotContains matches records whose attribute does not contain the substring.
+func NotContains(attribute, substring string) WhereItem {
+	return WhereItem{Type: "notContains", Attribute: attribute, Value: substring}
+}
+
+// StartsWith matches records whose attribute starts with prefix.
+func StartsWith(attribute, prefix string) WhereItem {
+	return WhereItem{Type: "startsWith", Attribute: attribute, Value: prefix}
+}
+
+// EndsWith matches records whose attribute ends with suffix.
+func EndsWith(attribute, suffix string) WhereItem {
+	return WhereItem{Type: "endsWith", Attribute: attribute, Value: suffix}
+}
+
+// Like matches records whose attribute matches an SQL LIKE pattern (% and _ wildcards).
+func Like(attribute, pattern string) WhereItem {
+	return WhereItem{Type: "like", Attribute: attribute, Value: pattern}
+}
+
+// In matches records whose attribute is one of values.
+func In(attribute string, values ...any) WhereItem {
+	return WhereItem{Type: "in", Attribute: attribute, Value: values}
+}
+
+// NotIn matches records whose attribute is none of values.
+func NotIn(attribute string, values ...any) WhereItem {
+	return WhereItem{Type: "notIn", Attribute: attribute, Value: values}
+}
+
+// GreaterThan matches records whose attribute is greater than value.
+func GreaterThan(attribute string, value any) WhereItem {
+	return WhereItem{Type: "greaterThan", Attribute: attribute, Value: value}
+}
+
+// GreaterThanOrEquals matches records whose attribute is at least value.
+func GreaterThanOrEquals(attribute string, value any) WhereItem {
+	return WhereItem{Type: "greaterThanOrEquals", Attribute: attribute, Value: value}
+}
+
+// LessThan matches records whose attribute is less than value.
+func LessThan(attribute string, value any) WhereItem {
+	return WhereItem{Type: "lessThan", Attribute: attribute, Value: value}
+}
+
+// LessThanOrEquals matches records whose attribute is at most value.
+func LessThanOrEquals(attribute string, value any) WhereItem {
+	return WhereItem{Type: "lessThanOrEquals", Attribute: attribute, Value: value}
+}
+
+// Between matches records whose attribute lies in the inclusive range [from, to].
+// For date fields, pass dates formatted with FormatDate.
+func Between(attribute string, from, to any) WhereItem {
+	return WhereItem{Type: "between", Attribute: attribute, Value: []any{from, to}}
+}
+
+// IsNull matches records whose attribute is empty.
+func IsNull(attribute string) WhereItem {
+	return WhereItem{Type: "isNull", Attribute: attribute}
+}
+
+// IsNotNull matches records whose attribute is set.
+func IsNotNull(attribute string) WhereItem {
+	return WhereItem{Type: "isNotNull", Attribute: attribute}
+}
+
+// IsTrue matches records whose boolean attribute is true.
+func IsTrue(attribute string) WhereItem {
+	return WhereItem{Type: "isTrue", Attribute: attribute}
+}
+
+// IsFalse matches records whose boolean attribute is false.
+func IsFalse(attribute string) WhereItem {
+	return WhereItem{Type: "isFalse", Attribute: attribute}
+}
+
+// LinkedWith matches records related through link to any of the foreign IDs.
+func LinkedWith(link string, ids ...string) WhereItem {
+	return WhereItem{Type: "linkedWith", Attribute: link, Value: ids}
+}
+
+// Or matches records satisfying at least one of the conditions.
+func Or(items ...WhereItem) WhereItem {
+	return WhereItem{Type: "or", Value: items}
+}
+
+// And matches records satisfying all of the conditions.
+func And(items ...WhereItem) WhereItem {
+	return WhereItem{Type: "and", Value: items}
+}
+
+// Not matches records satisfying none of the conditions.
+func Not(items ...WhereItem) WhereItem {
+	return WhereItem{Type: "not", Value: items}
+}
+
+// Filter appends conditions to the where clause; all of them must match.
+func (p *SearchParams) Filter(items ...WhereItem) *SearchParams {
+	p.Where = append(p.Where, items...)
+	return p
+}
+
+// Sort orders the results by attribute; order is "asc" or "desc".
+func (p *SearchParams) Sort(attribute, order string) *SearchParams {
+	p.OrderBy, p.Order = attribute, order
+	return p
+}
+
+// SortBy orders the results by attribute in the given order. EspoCRM sorts by a
+// single attribute; a later call replaces the earlier one.
+func (p *SearchParams) SortBy(attribute string, order SortOrder) *SearchParams {
+	p.OrderBy, p.Order = attribute, string(order)
+	return p
+}
+
+// Fields limits the attributes returned for each record.
+func (p *SearchParams) Fields(attributes ...string) *SearchParams {
+	p.Select = append(p.Select, attributes...)
+	return p
+}
+
+// PageSize sets the number of records fetched per request.
+func (p *SearchParams) PageSize(n int) *SearchParams {
+	p.MaxSize = n
+	return p
+}
+
+// Cap limits the total number of records returned when iterating.
+func (p *SearchParams) Cap(n int) *SearchParams {
+	p.Limit = n
+	return p
+}
+
+// Search sets the text filter, as typed in the search box of a list view.
+func (p *SearchParams) Search(text string) *SearchParams {
+	p.TextFilter = text
+	return p
+}
+
+// Primary sets the primary filter by name.
+func (p *SearchParams) Primary(name string) *SearchParams {
+	p.PrimaryFilter = name
+	return p
+}
+
+// Only adds bool filters, e.g. BoolFilterOnlyMy; all of them must match.
+func (p *SearchParams) Only(names ...string) *SearchParams {
+	p.BoolFilters = append(p.BoolFilters, names...)
+	return p
+}